@@ -3,8 +3,10 @@ module monkey
 go 1.17
 
 require (
+	github.com/mattn/go-runewidth v0.0.3
+	github.com/peterh/liner v1.2.2
 	github.com/stretchr/testify v1.7.1
-	github.com/xingshuo/console v0.0.0-20190501085718-a1c5edeb5c47
+	golang.org/x/sys v0.0.0-20211117180635-dee7805ff2e1
 )
 
 require (
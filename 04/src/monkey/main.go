@@ -1,13 +1,51 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"io"
+	evaluator "monkey/my_evaluator"
+	lexer "monkey/my_lexer"
+	object "monkey/my_object"
+	parser "monkey/my_parser"
 	repl "monkey/my_repl"
 	"os"
 	"os/user"
 )
 
+const usage = `Usage:
+  monkey                        start the repl (or run stdin if it is not a terminal)
+  monkey [file.mk|-] [args...]  run a script file, "-" reads it from stdin
+  monkey -e 'expr' [args...]    evaluate expr and print its result
+
+Remaining args are available to the script as the array "args".
+`
+
 func main() {
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	expr := flag.String("e", "", "evaluate `expr` instead of a script file")
+	strictFloat := flag.Bool("strict-float", false, "make float division by zero and overflow errors instead of inf or nan")
+	flag.Parse()
+	if *strictFloat {
+		evaluator.SetFloatMode(evaluator.FloatStrict)
+	}
+
+	// NOTE: -e '' is an empty program, not a missing -e
+	exprSet := false
+	flag.Visit(func(f *flag.Flag) { exprSet = exprSet || f.Name == "e" })
+
+	switch {
+	case exprSet:
+		os.Exit(run(*expr, flag.Args(), true, os.Stdout, os.Stderr))
+	case flag.NArg() > 0:
+		os.Exit(runFile(flag.Arg(0), flag.Args()[1:], os.Stdin, os.Stdout, os.Stderr))
+	case !repl.IsTerminal(os.Stdin):
+		os.Exit(runFile("-", nil, os.Stdin, os.Stdout, os.Stderr))
+	}
+
 	user, err := user.Current()
 	if err != nil {
 		panic(err)
@@ -17,3 +55,49 @@ func main() {
 	fmt.Printf("Feel free to type in commands\n")
 	repl.Start(os.Stdin, os.Stdout)
 }
+
+// runFile runs a script from path, or from stdin if path is "-"
+func runFile(path string, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	var (
+		src []byte
+		err error
+	)
+	if path == "-" {
+		src, err = io.ReadAll(stdin)
+	} else {
+		src, err = os.ReadFile(path)
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	return run(string(src), args, false, stdout, stderr)
+}
+
+// run evaluates src with args bound to "args" and returns the exit code;
+// parse and runtime errors are written to stderr with a non-zero code
+func run(src string, args []string, printResult bool, stdout, stderr io.Writer) int {
+	p := parser.New(lexer.New(src))
+	program := p.Parse()
+	if p.Error() != nil {
+		fmt.Fprintln(stderr, p.Error())
+		return 1
+	}
+
+	env := object.NewEnvironment()
+	argsObj, _ := evaluator.ToObject(args)
+	if argsObj == evaluator.NULL {
+		argsObj = &object.Array{Elements: []object.Object{}}
+	}
+	env.Set("args", argsObj)
+
+	evaluated := evaluator.Eval(program, env)
+	if errObj, eok := evaluated.(*object.Error); eok {
+		fmt.Fprintln(stderr, errObj.String())
+		return 1
+	}
+	if printResult && evaluated != nil && evaluated != evaluator.NULL {
+		fmt.Fprintln(stdout, evaluated.String())
+	}
+	return 0
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		src    string
+		args   []string
+		code   int
+		stdout string
+		stderr string
+	}{
+		{"1 + 2", nil, 0, "3\n", ""},
+		{"", nil, 0, "", ""},
+		{"null", nil, 0, "", ""},
+		{"len(args)", nil, 0, "0\n", ""},
+		{"args[1]", []string{"a", "b"}, 0, "b\n", ""},
+		{`"a" - "b"`, nil, 1, "", "ERROR: unknown operator: STRING-STRING\n"},
+		{"let = 1", nil, 1, "", ""},
+	}
+	for _, test := range tests {
+		stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+		code := run(test.src, test.args, true, stdout, stderr)
+		assert.Equal(t, test.code, code, "src: %s", test.src)
+		assert.Equal(t, test.stdout, stdout.String(), "src: %s", test.src)
+		if test.code == 0 {
+			assert.Empty(t, stderr.String(), "src: %s", test.src)
+		} else if test.stderr != "" {
+			assert.Equal(t, test.stderr, stderr.String(), "src: %s", test.src)
+		} else {
+			assert.NotEmpty(t, stderr.String(), "src: %s", test.src)
+		}
+	}
+}
+
+func TestRunFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "script.mk")
+	assert.NoError(t, os.WriteFile(path, []byte(`if (len(args) != 2) { args[5] + 1 }; args[0]`), 0o644))
+
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	// NOTE: scripts print nothing but what they puts
+	assert.Equal(t, 0, runFile(path, []string{"x", "y"}, nil, stdout, stderr))
+	assert.Empty(t, stdout.String())
+	assert.Empty(t, stderr.String())
+
+	assert.Equal(t, 1, runFile(path, nil, nil, stdout, stderr))
+	assert.NotEmpty(t, stderr.String())
+
+	stderr.Reset()
+	assert.Equal(t, 0, runFile("-", nil, strings.NewReader("let x = 1; x"), stdout, stderr))
+	assert.Empty(t, stderr.String())
+	assert.Equal(t, 1, runFile("-", nil, strings.NewReader("x"), stdout, stderr))
+	assert.Equal(t, "ERROR: identifier not found: x\n", stderr.String())
+
+	stderr.Reset()
+	assert.Equal(t, 1, runFile(filepath.Join(t.TempDir(), "missing.mk"), nil, nil, stdout, stderr))
+	assert.Contains(t, stderr.String(), "missing.mk")
+}
@@ -1,6 +1,7 @@
 package my_ast
 
 import (
+	"math/big"
 	token "monkey/my_token"
 	"strconv"
 	"strings"
@@ -155,6 +156,21 @@ func (i *Integer) String() string {
 	return strconv.FormatUint(i.Value, 10)
 }
 
+// BigInteger: an integer literal too large for Integer
+type BigInteger struct {
+	Value *big.Int
+}
+
+func (i *BigInteger) expressionNode() {}
+
+func (i *BigInteger) DebugString() string {
+	return i.Value.String()
+}
+
+func (i *BigInteger) String() string {
+	return i.Value.String()
+}
+
 type Float struct {
 	Value float64
 }
@@ -201,6 +217,7 @@ const (
 	INOP_PLUS       InfixOperator = token.PLUS
 	INOP_ASTERISK   InfixOperator = token.ASTERISK
 	INOP_SLASH      InfixOperator = token.SLASH
+	INOP_PERCENT    InfixOperator = token.PERCENT
 	INOP_LT         InfixOperator = token.LT
 	INOP_GT         InfixOperator = token.GT
 	INOP_EQ         InfixOperator = token.EQ
@@ -208,6 +225,10 @@ const (
 	INOP_CALL       InfixOperator = token.LPAREN
 	INOP_INDEX      InfixOperator = token.LBRACKET
 	INOP_INDEXCOLON InfixOperator = token.COLON
+	INOP_NULLISH    InfixOperator = token.NULLISH
+	INOP_DOT        InfixOperator = token.DOT
+	INOP_OPT_DOT    InfixOperator = token.OPT_DOT
+	INOP_OPT_INDEX  InfixOperator = token.OPT_LBRACKET
 )
 
 type InfixExpression struct {
@@ -246,6 +267,18 @@ func (b *Boolean) String() string {
 	return strconv.FormatBool(b.Value)
 }
 
+type Null struct{}
+
+func (n *Null) expressionNode() {}
+
+func (n *Null) DebugString() string {
+	return token.LookupKeywords(token.NULL)
+}
+
+func (n *Null) String() string {
+	return token.LookupKeywords(token.NULL)
+}
+
 type BlockStatement struct {
 	Statements []Statement
 }
@@ -356,6 +389,26 @@ func (sl *StringExpression) String() string { return sl.Value }
 
 func (sl *StringExpression) expressionNode() {}
 
+// InterpolatedString: "<TEXT>${<EXPR>}<TEXT>...", with one more text than expressions
+type InterpolatedString struct {
+	Texts []string
+	Exprs []Expression
+}
+
+func (is *InterpolatedString) DebugString() string { return is.String() }
+
+func (is *InterpolatedString) String() string {
+	sb := &strings.Builder{}
+	sb.WriteString("\"")
+	for idx, expr := range is.Exprs {
+		sb.WriteString(is.Texts[idx] + "${" + expr.String() + "}")
+	}
+	sb.WriteString(is.Texts[len(is.Exprs)] + "\"")
+	return sb.String()
+}
+
+func (is *InterpolatedString) expressionNode() {}
+
 type ArrayExpression struct {
 	Elements []Expression
 }
@@ -374,6 +427,28 @@ func (ae *ArrayExpression) String() string {
 
 func (ae *ArrayExpression) expressionNode() {}
 
+// TupleExpression: (<EXPR>, <EXPR>, ...), with a trailing comma if only one element
+type TupleExpression struct {
+	Elements []Expression
+}
+
+func (te *TupleExpression) DebugString() string {
+	return te.String()
+}
+
+func (te *TupleExpression) String() string {
+	elements := []string{}
+	for _, el := range te.Elements {
+		elements = append(elements, el.String())
+	}
+	if len(elements) == 1 {
+		return "(" + elements[0] + ",)"
+	}
+	return "(" + strings.Join(elements, ",") + ")"
+}
+
+func (te *TupleExpression) expressionNode() {}
+
 type IndexExpression struct {
 	Left            Expression
 	StartIndex      Expression // value specified by user for start index
@@ -382,6 +457,7 @@ type IndexExpression struct {
 	IsSetEndIndex   bool
 	Stride          Expression
 	IsSetStride     bool
+	Optional        bool // ?[ yields null instead of indexing a null Left
 }
 
 func (aie *IndexExpression) DebugString() string { return aie.String() }
@@ -390,6 +466,9 @@ func (aie *IndexExpression) String() string {
 	sb := &strings.Builder{}
 	sb.WriteRune('(')
 	sb.WriteString(aie.Left.String())
+	if aie.Optional {
+		sb.WriteRune('?')
+	}
 	sb.WriteRune('[')
 	if aie.StartIndex != nil {
 		sb.WriteString(aie.StartIndex.String())
@@ -412,6 +491,25 @@ func (aie *IndexExpression) String() string {
 
 func (aie *IndexExpression) expressionNode() {}
 
+// MemberExpression: <EXPR>.<IDENT> or <EXPR>?.<IDENT>, looks up the name as a string key
+type MemberExpression struct {
+	Left     Expression
+	Property *Identifier
+	Optional bool // ?. yields null instead of looking up on a null Left
+}
+
+func (me *MemberExpression) DebugString() string { return me.String() }
+
+func (me *MemberExpression) String() string {
+	dot := token.DOT
+	if me.Optional {
+		dot = token.OPT_DOT
+	}
+	return "(" + me.Left.String() + dot + me.Property.String() + ")"
+}
+
+func (me *MemberExpression) expressionNode() {}
+
 type HashExpression struct {
 	Pairs map[Expression]Expression
 	Keys  []Expression
@@ -21,3 +21,32 @@ func TestString(t *testing.T) {
 	}
 	assert.Equal(t, "let x = y;", prog.String())
 }
+
+func TestDump(t *testing.T) {
+	prog := &Program{
+		Statements: []Statement{
+			&LetStatement{
+				Ident: &Identifier{Value: "x"},
+				Value: &InfixExpression{
+					Operator: INOP_PLUS,
+					Left:     &Integer{Value: 1},
+					Right:    &StringExpression{Value: "a"},
+				},
+			},
+			&ExpressionStatement{
+				Expression: &HashExpression{
+					Pairs: map[Expression]Expression{},
+				},
+			},
+		},
+	}
+	expect := `Program
+  Statements[0]: LetStatement
+    Ident: Identifier Value="x"
+    Value: InfixExpression Operator="+"
+      Left: Integer Value=1
+      Right: StringExpression Value="a"
+  Statements[1]: ExpressionStatement
+    Expression: HashExpression`
+	assert.Equal(t, expect, Dump(prog))
+}
@@ -0,0 +1,78 @@
+package my_ast
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Dump: format node as an indented tree, one node per line,
+// with scalar fields inline and child nodes indented below their field name;
+// intended for debugging, e.g. the `:ast` command in repl
+func Dump(node Node) string {
+	sb := &strings.Builder{}
+	dumpNode(sb, "", node, 0)
+	return strings.TrimSuffix(sb.String(), NodeStringNewLine)
+}
+
+const dumpIndent = "  "
+
+var nodeType = reflect.TypeOf((*Node)(nil)).Elem()
+
+func dumpNode(sb *strings.Builder, label string, node Node, depth int) {
+	sb.WriteString(strings.Repeat(dumpIndent, depth))
+	if label != "" {
+		sb.WriteString(label)
+		sb.WriteString(": ")
+	}
+	rv := reflect.ValueOf(node)
+	if node == nil || (rv.Kind() == reflect.Ptr && rv.IsNil()) {
+		sb.WriteString("nil")
+		sb.WriteString(NodeStringNewLine)
+		return
+	}
+	elem := reflect.Indirect(rv)
+	sb.WriteString(elem.Type().Name())
+
+	// scalar fields inline, child nodes afterwards
+	type child struct {
+		label string
+		node  Node
+	}
+	children := []child{}
+	for idx := 0; idx < elem.NumField(); idx++ {
+		field := elem.Type().Field(idx)
+		value := elem.Field(idx)
+		switch {
+		case field.Type.Implements(nodeType):
+			if value.IsNil() {
+				continue
+			}
+			children = append(children, child{field.Name, value.Interface().(Node)})
+		case value.Kind() == reflect.Slice && field.Type.Elem().Implements(nodeType):
+			for i := 0; i < value.Len(); i++ {
+				children = append(children, child{
+					fmt.Sprintf("%s[%d]", field.Name, i), value.Index(i).Interface().(Node),
+				})
+			}
+		case value.Kind() == reflect.Map:
+			// NOTE: maps have no stable order; printed below if possible
+		case value.Kind() == reflect.String:
+			fmt.Fprintf(sb, " %s=%q", field.Name, value.String())
+		default:
+			fmt.Fprintf(sb, " %s=%v", field.Name, value.Interface())
+		}
+	}
+	sb.WriteString(NodeStringNewLine)
+
+	if he, ok := node.(*HashExpression); ok {
+		for idx, k := range he.Keys {
+			dumpNode(sb, fmt.Sprintf("Keys[%d]", idx), k, depth+1)
+			dumpNode(sb, fmt.Sprintf("Values[%d]", idx), he.Pairs[k], depth+1)
+		}
+		return
+	}
+	for _, c := range children {
+		dumpNode(sb, c.label, c.node, depth+1)
+	}
+}
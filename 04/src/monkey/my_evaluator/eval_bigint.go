@@ -0,0 +1,84 @@
+package my_evaluator
+
+import (
+	"math"
+	"math/big"
+	"monkey/my_ast"
+	"monkey/my_object"
+)
+
+// bigToIntegerObject: an Integer if v fits int64, a BigInteger otherwise,
+// so that every INT value has exactly one representation
+func bigToIntegerObject(v *big.Int) my_object.Object {
+	if v.IsInt64() {
+		return &my_object.Integer{Value: v.Int64()}
+	}
+	return &my_object.BigInteger{Value: v}
+}
+
+func bigIntegerToFloatObject(i *my_object.BigInteger) *my_object.Float {
+	f, _ := new(big.Float).SetInt(i.Value).Float64()
+	return &my_object.Float{Value: f}
+}
+
+// uintToIntegerObject: integer literals are parsed as uint64
+func uintToIntegerObject(v uint64) my_object.Object {
+	if v > math.MaxInt64 {
+		return &my_object.BigInteger{Value: new(big.Int).SetUint64(v)}
+	}
+	return &my_object.Integer{Value: int64(v)}
+}
+
+// addInt64, subInt64, mulInt64: false if the result overflows int64
+func addInt64(left, right int64) (int64, bool) {
+	sum := left + right
+	return sum, (sum > left) == (right > 0)
+}
+
+func subInt64(left, right int64) (int64, bool) {
+	diff := left - right
+	return diff, (diff < left) == (right > 0)
+}
+
+func mulInt64(left, right int64) (int64, bool) {
+	if left == 0 || right == 0 {
+		return 0, true
+	}
+	if (left == -1 && right == math.MinInt64) || (right == -1 && left == math.MinInt64) {
+		return 0, false
+	}
+	prod := left * right
+	return prod, prod/right == left
+}
+
+func evalBigIntegerInfixExpression(
+	operator my_ast.InfixOperator, left, right *big.Int,
+) my_object.Object {
+	switch operator {
+	case "+":
+		return bigToIntegerObject(new(big.Int).Add(left, right))
+	case "-":
+		return bigToIntegerObject(new(big.Int).Sub(left, right))
+	case "*":
+		return bigToIntegerObject(new(big.Int).Mul(left, right))
+	case "/", "%":
+		if right.Sign() == 0 {
+			return newError("division by zero")
+		}
+		// Quo and Rem truncate towards zero like int64 division
+		if operator == "/" {
+			return bigToIntegerObject(new(big.Int).Quo(left, right))
+		}
+		return bigToIntegerObject(new(big.Int).Rem(left, right))
+	case "<":
+		return nativeBoolToBooleanObject(left.Cmp(right) < 0)
+	case ">":
+		return nativeBoolToBooleanObject(left.Cmp(right) > 0)
+	case "==":
+		return nativeBoolToBooleanObject(left.Cmp(right) == 0)
+	case "!=":
+		return nativeBoolToBooleanObject(left.Cmp(right) != 0)
+	default:
+		return newError("unknown operator: %s%s%s", my_object.INTEGER_OBJ, operator, my_object.INTEGER_OBJ)
+	}
+}
@@ -0,0 +1,601 @@
+package my_evaluator
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"monkey/my_object"
+	"reflect"
+	"sort"
+)
+
+var (
+	objectType = reflect.TypeOf((*my_object.Object)(nil)).Elem()
+	errorType  = reflect.TypeOf((*error)(nil)).Elem()
+	bigIntType = reflect.TypeOf(big.Int{})
+)
+
+// ToObject converts a go value into a monkey object;
+// supported values are: nil, bool, integers, *big.Int, floats, string, error,
+// slices, arrays, maps (keys in sorted order), structs (exported fields keyed by name or `monkey` tag),
+// pointers to any of them, funcs (wrapped as builtins) and my_object.Object itself;
+// values referencing themselves are errors
+func ToObject(v interface{}) (my_object.Object, error) {
+	if v == nil {
+		return NULL, nil
+	}
+	return toObject(reflect.ValueOf(v))
+}
+
+// goConverter: converts go values into objects, remembering the pointers,
+// maps and slices being converted so self-referencing values are errors
+type goConverter struct {
+	seen map[goRef]bool
+}
+
+type goRef struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+func toObject(rv reflect.Value) (my_object.Object, error) {
+	c := &goConverter{seen: map[goRef]bool{}}
+	return c.convert(rv)
+}
+
+// enter: reports whether rv is already being converted further up,
+// otherwise marks it until leave
+func (c *goConverter) enter(rv reflect.Value) bool {
+	ref := goRef{rv.Pointer(), rv.Type()}
+	if c.seen[ref] {
+		return true
+	}
+	c.seen[ref] = true
+	return false
+}
+
+func (c *goConverter) leave(rv reflect.Value) {
+	delete(c.seen, goRef{rv.Pointer(), rv.Type()})
+}
+
+func (c *goConverter) convert(rv reflect.Value) (my_object.Object, error) {
+	if !rv.IsValid() {
+		return NULL, nil
+	}
+	if rv.Type().Implements(objectType) {
+		if (rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface) && rv.IsNil() {
+			return NULL, nil
+		}
+		return rv.Interface().(my_object.Object), nil
+	}
+	if rv.Type().Implements(errorType) {
+		if (rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface) && rv.IsNil() {
+			return NULL, nil
+		}
+		return newError("%s", rv.Interface().(error).Error()), nil
+	}
+	if rv.Type() == bigIntType {
+		v := rv.Interface().(big.Int)
+		return bigToIntegerObject(new(big.Int).Set(&v)), nil
+	}
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if rv.IsNil() {
+			return NULL, nil
+		}
+		if rv.Kind() == reflect.Ptr {
+			if c.enter(rv) {
+				return nil, errors.New("cannot convert cyclic structure")
+			}
+			defer c.leave(rv)
+		}
+		return c.convert(rv.Elem())
+	case reflect.Bool:
+		return nativeBoolToBooleanObject(rv.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &my_object.Integer{Value: rv.Int()}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return uintToIntegerObject(rv.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return &my_object.Float{Value: rv.Float()}, nil
+	case reflect.String:
+		return &my_object.String{Value: rv.String()}, nil
+	case reflect.Slice:
+		if rv.IsNil() {
+			return NULL, nil
+		}
+		if c.enter(rv) {
+			return nil, errors.New("cannot convert cyclic structure")
+		}
+		defer c.leave(rv)
+		fallthrough
+	case reflect.Array:
+		elements := make([]my_object.Object, 0, rv.Len())
+		for idx := 0; idx < rv.Len(); idx++ {
+			elem, err := c.convert(rv.Index(idx))
+			if err != nil {
+				return nil, fmt.Errorf("index %d: %w", idx, err)
+			}
+			elements = append(elements, elem)
+		}
+		return &my_object.Array{Elements: elements}, nil
+	case reflect.Map:
+		if rv.IsNil() {
+			return NULL, nil
+		}
+		if c.enter(rv) {
+			return nil, errors.New("cannot convert cyclic structure")
+		}
+		defer c.leave(rv)
+		hash := my_object.NewHash()
+		for _, mapKey := range sortedMapKeys(rv) {
+			key, err := c.convert(mapKey)
+			if err != nil {
+				return nil, fmt.Errorf("key %v: %w", mapKey, err)
+			}
+			hashableKey, hok := my_object.AsHashable(key)
+			if !hok {
+				return nil, fmt.Errorf("key type not hashable: %s", key.Type())
+			}
+			value, err := c.convert(rv.MapIndex(mapKey))
+			if err != nil {
+				return nil, fmt.Errorf("key %v: %w", mapKey, err)
+			}
+			hash.Set(hashableKey, value)
+		}
+		return hash, nil
+	case reflect.Struct:
+		hash := my_object.NewHash()
+		for idx := 0; idx < rv.NumField(); idx++ {
+			name, ok := structFieldName(rv.Type().Field(idx))
+			if !ok {
+				continue
+			}
+			value, err := c.convert(rv.Field(idx))
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", name, err)
+			}
+			hash.Set(&my_object.String{Value: name}, value)
+		}
+		return hash, nil
+	case reflect.Func:
+		if rv.IsNil() {
+			return NULL, nil
+		}
+		return wrapFunc("", rv)
+	default:
+		return nil, fmt.Errorf("cannot convert go type %s to monkey object", rv.Type())
+	}
+}
+
+// sortedMapKeys: keys of the map rv in a stable order, as go ranges over maps randomly;
+// numbers and strings are compared by value, other keys by type and then by their %v form
+func sortedMapKeys(rv reflect.Value) []reflect.Value {
+	keys := rv.MapKeys()
+	sort.SliceStable(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		for a.Kind() == reflect.Interface && !a.IsNil() {
+			a = a.Elem()
+		}
+		for b.Kind() == reflect.Interface && !b.IsNil() {
+			b = b.Elem()
+		}
+		if a.Kind() == b.Kind() {
+			switch a.Kind() {
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				return a.Int() < b.Int()
+			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+				return a.Uint() < b.Uint()
+			case reflect.Float32, reflect.Float64:
+				return a.Float() < b.Float()
+			case reflect.String:
+				return a.String() < b.String()
+			}
+		}
+		if ta, tb := a.Type().String(), b.Type().String(); ta != tb {
+			return ta < tb
+		}
+		return fmt.Sprint(a) < fmt.Sprint(b)
+	})
+	return keys
+}
+
+// FromObject converts a monkey object into a go value;
+// INT yields int64 or *big.Int if it does not fit, FLOAT float64, STRING string, BOOLEAN bool, NULL nil,
+// ARRAY and TUPLE []interface{}, ERROR error;
+// HASH yields map[string]interface{} if all keys are strings,
+// otherwise map[interface{}]interface{};
+// FUNCTION and BUILTIN are returned as they are for later calls
+func FromObject(obj my_object.Object) (interface{}, error) {
+	switch obj := obj.(type) {
+	case nil, *my_object.Null:
+		return nil, nil
+	case *my_object.Integer:
+		return obj.Value, nil
+	case *my_object.BigInteger:
+		return new(big.Int).Set(obj.Value), nil
+	case *my_object.Float:
+		return obj.Value, nil
+	case *my_object.String:
+		return obj.Value, nil
+	case *my_object.Boolean:
+		return obj.Value, nil
+	case *my_object.Error:
+		return errors.New(obj.Message), nil
+	case *my_object.ReturnValue:
+		return FromObject(obj.Value)
+	case *my_object.Function, *my_object.Builtin:
+		return obj, nil
+	case *my_object.Array, *my_object.Tuple:
+		objs, _ := elementsOf(obj)
+		elements := make([]interface{}, 0, len(objs))
+		for idx, e := range objs {
+			elem, err := FromObject(e)
+			if err != nil {
+				return nil, fmt.Errorf("index %d: %w", idx, err)
+			}
+			elements = append(elements, elem)
+		}
+		return elements, nil
+	case *my_object.Hash:
+		allStringKeys := true
+		for _, pair := range obj.Pairs() {
+			if _, sok := pair.Key.(*my_object.String); !sok {
+				allStringKeys = false
+				break
+			}
+		}
+		if allStringKeys {
+			values := make(map[string]interface{}, obj.Len())
+			for _, pair := range obj.Pairs() {
+				value, err := FromObject(pair.Value)
+				if err != nil {
+					return nil, fmt.Errorf("key %s: %w", pair.Key.String(), err)
+				}
+				values[pair.Key.(*my_object.String).Value] = value
+			}
+			return values, nil
+		}
+		values := make(map[interface{}]interface{}, obj.Len())
+		for _, pair := range obj.Pairs() {
+			key, err := FromObject(pair.Key)
+			if err != nil {
+				return nil, fmt.Errorf("key %s: %w", pair.Key.String(), err)
+			}
+			// NOTE: tuples, frozen arrays and hashes have no comparable go counterpart
+			if !reflect.TypeOf(key).Comparable() {
+				return nil, fmt.Errorf("key %s: cannot convert %s to go map key", pair.Key.String(), pair.Key.Type())
+			}
+			value, err := FromObject(pair.Value)
+			if err != nil {
+				return nil, fmt.Errorf("key %s: %w", pair.Key.String(), err)
+			}
+			values[key] = value
+		}
+		return values, nil
+	default:
+		return nil, fmt.Errorf("cannot convert monkey object %s to go value", obj.Type())
+	}
+}
+
+// fromObjectTo converts a monkey object into a go value of the given type
+func fromObjectTo(obj my_object.Object, t reflect.Type) (reflect.Value, error) {
+	if t.Kind() == reflect.Interface && t.NumMethod() == 0 {
+		v, err := FromObject(obj)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if v == nil {
+			return reflect.Zero(t), nil
+		}
+		return reflect.ValueOf(v), nil
+	}
+	if reflect.TypeOf(obj).AssignableTo(t) {
+		return reflect.ValueOf(obj), nil
+	}
+	if t == errorType {
+		if errObj, eok := obj.(*my_object.Error); eok {
+			return reflect.ValueOf(errors.New(errObj.Message)), nil
+		}
+		if obj == NULL {
+			return reflect.Zero(t), nil
+		}
+		return reflect.Value{}, typeMismatchError(obj, t)
+	}
+	if t == bigIntType {
+		switch num := obj.(type) {
+		case *my_object.Integer:
+			return reflect.ValueOf(*big.NewInt(num.Value)), nil
+		case *my_object.BigInteger:
+			return reflect.ValueOf(*new(big.Int).Set(num.Value)), nil
+		}
+		return reflect.Value{}, typeMismatchError(obj, t)
+	}
+	switch t.Kind() {
+	case reflect.Ptr:
+		if obj == NULL {
+			return reflect.Zero(t), nil
+		}
+		elem, err := fromObjectTo(obj, t.Elem())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		ptr := reflect.New(t.Elem())
+		ptr.Elem().Set(elem)
+		return ptr, nil
+	case reflect.Bool:
+		if b, bok := obj.(*my_object.Boolean); bok {
+			return reflect.ValueOf(b.Value).Convert(t), nil
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if i, iok := obj.(*my_object.Integer); iok {
+			v := reflect.New(t).Elem()
+			if v.OverflowInt(i.Value) {
+				return reflect.Value{}, fmt.Errorf("cannot convert %d to %s: overflow", i.Value, t)
+			}
+			v.SetInt(i.Value)
+			return v, nil
+		}
+		if i, iok := obj.(*my_object.BigInteger); iok {
+			return reflect.Value{}, fmt.Errorf("cannot convert %s to %s: overflow", i.Value, t)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if i, iok := obj.(*my_object.Integer); iok {
+			v := reflect.New(t).Elem()
+			if i.Value < 0 || v.OverflowUint(uint64(i.Value)) {
+				return reflect.Value{}, fmt.Errorf("cannot convert %d to %s: overflow", i.Value, t)
+			}
+			v.SetUint(uint64(i.Value))
+			return v, nil
+		}
+		if i, iok := obj.(*my_object.BigInteger); iok {
+			v := reflect.New(t).Elem()
+			if !i.Value.IsUint64() || v.OverflowUint(i.Value.Uint64()) {
+				return reflect.Value{}, fmt.Errorf("cannot convert %s to %s: overflow", i.Value, t)
+			}
+			v.SetUint(i.Value.Uint64())
+			return v, nil
+		}
+	case reflect.Float32, reflect.Float64:
+		switch num := obj.(type) {
+		case *my_object.Float:
+			return reflect.ValueOf(num.Value).Convert(t), nil
+		case *my_object.Integer:
+			return reflect.ValueOf(float64(num.Value)).Convert(t), nil
+		case *my_object.BigInteger:
+			return reflect.ValueOf(bigIntegerToFloatObject(num).Value).Convert(t), nil
+		}
+	case reflect.String:
+		if s, sok := obj.(*my_object.String); sok {
+			return reflect.ValueOf(s.Value).Convert(t), nil
+		}
+	case reflect.Slice:
+		if obj == NULL {
+			return reflect.Zero(t), nil
+		}
+		if elements, ok := elementsOf(obj); ok {
+			v := reflect.MakeSlice(t, len(elements), len(elements))
+			for idx, e := range elements {
+				elem, err := fromObjectTo(e, t.Elem())
+				if err != nil {
+					return reflect.Value{}, fmt.Errorf("index %d: %w", idx, err)
+				}
+				v.Index(idx).Set(elem)
+			}
+			return v, nil
+		}
+	case reflect.Array:
+		if elements, ok := elementsOf(obj); ok {
+			if len(elements) != t.Len() {
+				return reflect.Value{}, fmt.Errorf(
+					"cannot convert %s with length %d to %s", obj.Type(), len(elements), t,
+				)
+			}
+			v := reflect.New(t).Elem()
+			for idx, e := range elements {
+				elem, err := fromObjectTo(e, t.Elem())
+				if err != nil {
+					return reflect.Value{}, fmt.Errorf("index %d: %w", idx, err)
+				}
+				v.Index(idx).Set(elem)
+			}
+			return v, nil
+		}
+	case reflect.Map:
+		if obj == NULL {
+			return reflect.Zero(t), nil
+		}
+		if hash, hok := obj.(*my_object.Hash); hok {
+			v := reflect.MakeMapWithSize(t, hash.Len())
+			for _, pair := range hash.Pairs() {
+				key, err := fromObjectTo(pair.Key, t.Key())
+				if err != nil {
+					return reflect.Value{}, fmt.Errorf("key %s: %w", pair.Key.String(), err)
+				}
+				value, err := fromObjectTo(pair.Value, t.Elem())
+				if err != nil {
+					return reflect.Value{}, fmt.Errorf("key %s: %w", pair.Key.String(), err)
+				}
+				v.SetMapIndex(key, value)
+			}
+			return v, nil
+		}
+	case reflect.Struct:
+		if hash, hok := obj.(*my_object.Hash); hok {
+			v := reflect.New(t).Elem()
+			for idx := 0; idx < t.NumField(); idx++ {
+				name, ok := structFieldName(t.Field(idx))
+				if !ok {
+					continue
+				}
+				pair, pok := hash.Get(&my_object.String{Value: name})
+				if !pok {
+					continue
+				}
+				field, err := fromObjectTo(pair.Value, t.Field(idx).Type)
+				if err != nil {
+					return reflect.Value{}, fmt.Errorf("field %s: %w", name, err)
+				}
+				v.Field(idx).Set(field)
+			}
+			return v, nil
+		}
+	}
+	return reflect.Value{}, typeMismatchError(obj, t)
+}
+
+// elementsOf: elements of an ARRAY or TUPLE, false for other objects
+func elementsOf(obj my_object.Object) ([]my_object.Object, bool) {
+	switch obj := obj.(type) {
+	case *my_object.Array:
+		return obj.Elements, true
+	case *my_object.Tuple:
+		return obj.Elements, true
+	}
+	return nil, false
+}
+
+func typeMismatchError(obj my_object.Object, t reflect.Type) error {
+	return fmt.Errorf("cannot convert %s to %s", obj.Type(), t)
+}
+
+// structFieldName: name of an exported struct field as a hash key;
+// a `monkey:"name"` tag overrides the field name and `monkey:"-"` skips it
+func structFieldName(field reflect.StructField) (string, bool) {
+	if field.PkgPath != "" {
+		return "", false
+	}
+	tag := field.Tag.Get("monkey")
+	if tag == "-" {
+		return "", false
+	}
+	if tag != "" {
+		return tag, true
+	}
+	return field.Name, true
+}
+
+// RegisterFunc registers a go function as a builtin under name;
+// arguments are converted from monkey objects to the parameter types,
+// with arity and types checked on each call;
+// fn may return nothing, one value, or one value followed by an error;
+// a non-nil error returned by fn is turned into an ERROR object;
+// name must not be taken by a builtin or a module, see OverrideFunc
+func RegisterFunc(name string, fn interface{}) error {
+	return registerFunc(name, fn, false)
+}
+
+// OverrideFunc is like RegisterFunc but replaces a builtin or shadows a module
+// already named name, for every caller in the process
+func OverrideFunc(name string, fn interface{}) error {
+	return registerFunc(name, fn, true)
+}
+
+func registerFunc(name string, fn interface{}, override bool) error {
+	builtin, err := WrapFunc(name, fn)
+	if err != nil {
+		return err
+	}
+	builtinsMu.Lock()
+	defer builtinsMu.Unlock()
+	if !override {
+		if _, ok := builtins[name]; ok {
+			return fmt.Errorf("cannot register %s: builtin already exists", name)
+		}
+		if _, ok := modules[name]; ok {
+			return fmt.Errorf("cannot register %s: module already exists", name)
+		}
+	}
+	builtins[name] = builtin
+	return nil
+}
+
+// WrapFunc wraps a go function as a builtin without registering it;
+// see RegisterFunc for what kind of functions are accepted
+func WrapFunc(name string, fn interface{}) (*my_object.Builtin, error) {
+	rv := reflect.ValueOf(fn)
+	if rv.Kind() != reflect.Func || rv.IsNil() {
+		return nil, fmt.Errorf("cannot wrap %T as builtin: not a function", fn)
+	}
+	return wrapFunc(name, rv)
+}
+
+func wrapFunc(name string, fn reflect.Value) (*my_object.Builtin, error) {
+	ft := fn.Type()
+	switch {
+	case ft.NumOut() > 2:
+		return nil, fmt.Errorf("cannot wrap %s as builtin: too many return values", ft)
+	case ft.NumOut() == 2 && ft.Out(1) != errorType:
+		return nil, fmt.Errorf("cannot wrap %s as builtin: second return value must be error", ft)
+	}
+	// label: name used in error messages
+	label := name
+	if label == "" {
+		label = ft.String()
+	}
+	return &my_object.Builtin{
+		Name: name,
+		Fn: func(args ...my_object.Object) my_object.Object {
+			in, errObj := funcArguments(label, ft, args)
+			if errObj != nil {
+				return errObj
+			}
+			return callFunc(label, fn, in)
+		},
+	}, nil
+}
+
+func funcArguments(name string, ft reflect.Type, args []my_object.Object) ([]reflect.Value, *my_object.Error) {
+	numIn := ft.NumIn()
+	if ft.IsVariadic() {
+		if len(args) < numIn-1 {
+			return nil, newError("wrong number of arguments: got=%d, want>=%d", len(args), numIn-1)
+		}
+	} else if len(args) != numIn {
+		return nil, newError("wrong number of arguments: got=%d, want=%d", len(args), numIn)
+	}
+	in := make([]reflect.Value, 0, len(args))
+	for idx, arg := range args {
+		var argType reflect.Type
+		if ft.IsVariadic() && idx >= numIn-1 {
+			argType = ft.In(numIn - 1).Elem()
+		} else {
+			argType = ft.In(idx)
+		}
+		if isError(arg) && argType != errorType && !objectType.AssignableTo(argType) {
+			return nil, arg.(*my_object.Error)
+		}
+		v, err := fromObjectTo(arg, argType)
+		if err != nil {
+			return nil, newError("argument %d to `%s` not supported: %s", idx+1, name, err.Error())
+		}
+		in = append(in, v)
+	}
+	return in, nil
+}
+
+func callFunc(name string, fn reflect.Value, in []reflect.Value) (result my_object.Object) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = newError("panic in `%s`: %v", name, r)
+		}
+	}()
+	out := fn.Call(in)
+	if len(out) == 0 {
+		return NULL
+	}
+	if last := out[len(out)-1]; last.Type() == errorType {
+		if !last.IsNil() {
+			return newError("%s", last.Interface().(error).Error())
+		}
+		out = out[:len(out)-1]
+		if len(out) == 0 {
+			return NULL
+		}
+	}
+	obj, err := toObject(out[0])
+	if err != nil {
+		return newError("result of `%s` not supported: %s", name, err.Error())
+	}
+	return obj
+}
@@ -0,0 +1,234 @@
+package my_evaluator
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"monkey/my_object"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type bridgePoint struct {
+	X      int
+	Y      int
+	Label  string `monkey:"label"`
+	hidden bool
+}
+
+func TestToObject(t *testing.T) {
+	obj, err := ToObject(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, NULL, obj)
+
+	obj, err = ToObject(true)
+	assert.NoError(t, err)
+	assert.Equal(t, TRUE, obj)
+
+	obj, err = ToObject(uint8(7))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 7, obj.(*my_object.Integer).Value)
+
+	obj, err = ToObject([]interface{}{1, 2.5, "three", nil})
+	assert.NoError(t, err)
+	assert.Equal(t, "[1,2.5,three,null]", obj.String())
+
+	obj, err = ToObject(map[string]int{"one": 1})
+	assert.NoError(t, err)
+	assert.Equal(t, "{one:1}", obj.String())
+
+	// NOTE: go ranges over maps in random order, the keys must come out sorted every time
+	for i := 0; i < 20; i++ {
+		obj, err = ToObject(map[string]int{"d": 4, "b": 2, "a": 1, "c": 3, "e": 5})
+		assert.NoError(t, err)
+		assert.Equal(t, "{a:1,b:2,c:3,d:4,e:5}", obj.String())
+
+		obj, err = ToObject(map[int]string{10: "ten", -1: "minus one", 2: "two"})
+		assert.NoError(t, err)
+		assert.Equal(t, "{-1:minus one,2:two,10:ten}", obj.String())
+
+		obj, err = ToObject(map[interface{}]int{"b": 2, 3: 3, "a": 1, 1: 1, true: 0})
+		assert.NoError(t, err)
+		assert.Equal(t, "{true:0,1:1,3:3,a:1,b:2}", obj.String())
+	}
+
+	obj, err = ToObject(&bridgePoint{X: 1, Y: 2, Label: "p"})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"X": 1, "Y": 2, "label": "p"}`, obj.Inspect())
+
+	obj, err = ToObject(errors.New("boom"))
+	assert.NoError(t, err)
+	assert.Equal(t, "boom", obj.(*my_object.Error).Message)
+
+	obj, err = ToObject(uint64(math.MaxUint64))
+	assert.NoError(t, err)
+	assert.Equal(t, "18446744073709551615", obj.(*my_object.BigInteger).String())
+
+	obj, err = ToObject(big.NewInt(42))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 42, obj.(*my_object.Integer).Value)
+
+	_, err = ToObject(make(chan int))
+	assert.Error(t, err)
+}
+
+func TestFromObject(t *testing.T) {
+	evaluated := testEval(t, `[1, 2.5, "three", true, {"a": [1]}, {}["missing"]]`)
+	v, err := FromObject(evaluated)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{
+		int64(1), 2.5, "three", true, map[string]interface{}{"a": []interface{}{int64(1)}}, nil,
+	}, v)
+
+	v, err = FromObject(testEval(t, "9223372036854775807 + 1"))
+	assert.NoError(t, err)
+	expect, _ := new(big.Int).SetString("9223372036854775808", 10)
+	assert.Equal(t, expect, v)
+
+	v, err = FromObject(testEval(t, `{1: "one"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, map[interface{}]interface{}{int64(1): "one"}, v)
+
+	v, err = FromObject(testEval(t, `items({"a": 1, "b": (2, "x")})`))
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{
+		[]interface{}{"a", int64(1)}, []interface{}{"b", []interface{}{int64(2), "x"}},
+	}, v)
+
+	_, err = FromObject(testEval(t, `{(1, 2): "pair"}`))
+	assert.EqualError(t, err, "key (1,2): cannot convert TUPLE to go map key")
+}
+
+func TestTupleThroughBridge(t *testing.T) {
+	result, err := Call(testEval(t, "enumerate"), []string{"a", "b"})
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{[]interface{}{int64(0), "a"}, []interface{}{int64(1), "b"}}, result)
+
+	result, err = Call(testEval(t, "fn(x) { (x, x * 2) }"), 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{int64(3), int64(6)}, result)
+
+	unregisterOnCleanup(t, "goPairSum")
+	assert.NoError(t, RegisterFunc("goPairSum", func(pair [2]int, rest []float64) float64 {
+		sum := float64(pair[0] + pair[1])
+		for _, r := range rest {
+			sum += r
+		}
+		return sum
+	}))
+	testCaseWithStruct(t, []*testCaseTyped{
+		{`goPairSum((1, 2), (0.5,))`, 3.5, floatType},
+		{`goPairSum((1, 2, 3), [])`, "argument 1 to `goPairSum` not supported: cannot convert TUPLE with length 3 to [2]int", errType},
+	})
+}
+
+// unregisterOnCleanup: removes builtins registered by a test once it is done,
+// so that it can run again with go test -count
+func unregisterOnCleanup(t *testing.T, names ...string) {
+	t.Cleanup(func() {
+		builtinsMu.Lock()
+		defer builtinsMu.Unlock()
+		for _, name := range names {
+			delete(builtins, name)
+		}
+	})
+}
+
+func TestRegisterFunc(t *testing.T) {
+	unregisterOnCleanup(t, "goRepeat", "goDiv", "goSum", "goNorm", "goPoint", "goBig", "goUint")
+	assert.NoError(t, RegisterFunc("goRepeat", strings.Repeat))
+	assert.NoError(t, RegisterFunc("goDiv", func(a, b int) (int, error) {
+		if b == 0 {
+			return 0, errors.New("division by zero")
+		}
+		return a / b, nil
+	}))
+	assert.NoError(t, RegisterFunc("goSum", func(nums ...float64) float64 {
+		sum := 0.0
+		for _, n := range nums {
+			sum += n
+		}
+		return sum
+	}))
+	assert.NoError(t, RegisterFunc("goNorm", func(p bridgePoint) int {
+		return p.X*p.X + p.Y*p.Y
+	}))
+	assert.NoError(t, RegisterFunc("goPoint", func(x, y int) *bridgePoint {
+		return &bridgePoint{X: x, Y: y}
+	}))
+	assert.NoError(t, RegisterFunc("goBig", func(n *big.Int) string {
+		return new(big.Int).Add(n, big.NewInt(1)).String()
+	}))
+	assert.NoError(t, RegisterFunc("goUint", func(n uint64) string {
+		return strconv.FormatUint(n, 10)
+	}))
+	assert.Error(t, RegisterFunc("notFunc", 1))
+	assert.Error(t, RegisterFunc("badReturn", func() (int, int) { return 1, 1 }))
+	assert.EqualError(t, RegisterFunc("goRepeat", strings.Repeat), "cannot register goRepeat: builtin already exists")
+	assert.EqualError(t, RegisterFunc("len", strings.Count), "cannot register len: builtin already exists")
+	assert.EqualError(t, RegisterFunc("math", strings.Count), "cannot register math: module already exists")
+
+	tests := []*testCaseTyped{
+		{`goRepeat("ab", 3)`, "ababab", strType},
+		{`goDiv(7, 2)`, 3, intType},
+		{`goDiv(7, 0)`, "division by zero", errType},
+		{`goSum()`, 0, floatType},
+		{`goSum(1, 2.5)`, 3.5, floatType},
+		{`goNorm({"X": 3, "Y": 4})`, 25, intType},
+		{`goNorm(goPoint(1, 2))`, 5, intType},
+		{`goBig(9223372036854775807)`, "9223372036854775808", strType},
+		{`goBig(18446744073709551615 * 2)`, "36893488147419103231", strType},
+		{`goUint(18446744073709551615)`, "18446744073709551615", strType},
+		{`goDiv(18446744073709551615, 1)`, "argument 1 to `goDiv` not supported: cannot convert 18446744073709551615 to int: overflow", errType},
+		{`goRepeat("ab")`, "wrong number of arguments: got=1, want=2", errType},
+		{`goRepeat(1, 2)`, "argument 1 to `goRepeat` not supported: cannot convert INT to string", errType},
+	}
+	testCaseWithStruct(t, tests)
+}
+
+func TestOverrideFunc(t *testing.T) {
+	builtinsMu.RLock()
+	original := builtins["byteLen"]
+	builtinsMu.RUnlock()
+	t.Cleanup(func() {
+		builtinsMu.Lock()
+		defer builtinsMu.Unlock()
+		builtins["byteLen"] = original
+	})
+
+	assert.NoError(t, OverrideFunc("byteLen", func(s string) int { return -len(s) }))
+	testCaseWithStruct(t, []*testCaseTyped{
+		{`byteLen("héllo")`, -6, intType},
+	})
+}
+
+type bridgeNode struct {
+	Value int
+	Next  *bridgeNode
+}
+
+func TestToObjectCycles(t *testing.T) {
+	// NOTE: shared but not cyclic values convert as they are
+	shared := &bridgeNode{Value: 1}
+	obj, err := ToObject([]*bridgeNode{shared, shared, {Value: 2, Next: shared}})
+	assert.NoError(t, err)
+	assert.Equal(t, `[{"Value": 1, "Next": null}, {"Value": 1, "Next": null}, {"Value": 2, "Next": {"Value": 1, "Next": null}}]`, obj.Inspect())
+
+	node := &bridgeNode{Value: 1}
+	node.Next = &bridgeNode{Value: 2, Next: node}
+	_, err = ToObject(node)
+	assert.EqualError(t, err, "field Next: field Next: cannot convert cyclic structure")
+
+	list := []interface{}{1, nil}
+	list[1] = list
+	_, err = ToObject(list)
+	assert.EqualError(t, err, "index 1: cannot convert cyclic structure")
+
+	hash := map[string]interface{}{}
+	hash["self"] = hash
+	_, err = ToObject(hash)
+	assert.EqualError(t, err, "key self: cannot convert cyclic structure")
+}
@@ -3,8 +3,46 @@ package my_evaluator
 import (
 	"fmt"
 	"monkey/my_object"
+	"sort"
+	"sync"
+	"unicode/utf8"
 )
 
+// builtinsMu guards builtins against concurrent RegisterFunc calls
+var builtinsMu sync.RWMutex
+
+// BuiltinNames: sorted names of all registered builtins
+func BuiltinNames() []string {
+	builtinsMu.RLock()
+	defer builtinsMu.RUnlock()
+	names := make([]string, 0, len(builtins))
+	for name := range builtins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func init() {
+	for _, group := range []map[string]*my_object.Builtin{stringBuiltins, arrayBuiltins, hashBuiltins, typeBuiltins} {
+		for name, builtin := range group {
+			builtins[name] = builtin
+		}
+	}
+	for name, builtin := range builtins {
+		builtin.Name = name
+	}
+	modules["math"] = newModule("math", mathMembers)
+	modules["json"] = newModule("json", jsonMembers)
+}
+
+func lookupBuiltin(name string) (*my_object.Builtin, bool) {
+	builtinsMu.RLock()
+	defer builtinsMu.RUnlock()
+	fn, ok := builtins[name]
+	return fn, ok
+}
+
 var builtins = map[string]*my_object.Builtin{
 	"len": {
 		Fn: func(args ...my_object.Object) my_object.Object {
@@ -13,28 +51,122 @@ var builtins = map[string]*my_object.Builtin{
 			}
 			switch arg := args[0].(type) {
 			case *my_object.String:
-				return &my_object.Integer{Value: int64(len(arg.Value))}
+				// NOTE: in runes, as strings are indexed and sliced
+				return &my_object.Integer{Value: int64(utf8.RuneCountInString(arg.Value))}
 			case *my_object.Array:
 				return &my_object.Integer{Value: int64(len(arg.Elements))}
+			case *my_object.Tuple:
+				return &my_object.Integer{Value: int64(len(arg.Elements))}
 			default:
 				return newError("argument to len not supported: got %s", arg.Type())
 			}
 		},
 	},
+	"byteLen": {
+		Fn: func(args ...my_object.Object) my_object.Object {
+			if len(args) != 1 {
+				return newError("wrong number of arguments: got=%d, want=1", len(args))
+			}
+			str, ok := args[0].(*my_object.String)
+			if !ok {
+				return newError("argument to byteLen not supported: got %s", args[0].Type())
+			}
+			return &my_object.Integer{Value: int64(len(str.Value))}
+		},
+	},
+	"bytes": {
+		Fn: func(args ...my_object.Object) my_object.Object {
+			if len(args) != 1 {
+				return newError("wrong number of arguments: got=%d, want=1", len(args))
+			}
+			str, ok := args[0].(*my_object.String)
+			if !ok {
+				return newError("argument to bytes not supported: got %s", args[0].Type())
+			}
+			elements := make([]my_object.Object, 0, len(str.Value))
+			for idx := 0; idx < len(str.Value); idx++ {
+				elements = append(elements, &my_object.Integer{Value: int64(str.Value[idx])})
+			}
+			return &my_object.Array{Elements: elements}
+		},
+	},
+	// NOTE: the inverse of bytes, so invalid UTF-8 is kept as it is, as in "\xff"
+	"fromBytes": {
+		Fn: func(args ...my_object.Object) my_object.Object {
+			if len(args) != 1 {
+				return newError("wrong number of arguments: got=%d, want=1", len(args))
+			}
+			arr, ok := args[0].(*my_object.Array)
+			if !ok {
+				return newError("argument to fromBytes not supported: got %s", args[0].Type())
+			}
+			buf := make([]byte, 0, len(arr.Elements))
+			for _, elem := range arr.Elements {
+				b, iok := elem.(*my_object.Integer)
+				if !iok || b.Value < 0 || b.Value > 255 {
+					return newError("fromBytes expecting bytes in 0..255, but got %s", elem.Inspect())
+				}
+				buf = append(buf, byte(b.Value))
+			}
+			return &my_object.String{Value: string(buf)}
+		},
+	},
 	"append": {
 		Fn: func(args ...my_object.Object) my_object.Object {
-			if len(args) != 2 {
-				return newError("wrong number of arguments: got=%d, want=2", len(args))
+			if len(args) < 2 {
+				return newError("wrong number of arguments: got=%d, want>=2", len(args))
 			}
 			if args[0].Type() != my_object.ARRAY_OBJ {
 				return newError("first argument to `append` must be ARRAY: got=%s", args[0].Type())
 			}
-			newElements := make([]my_object.Object, len(args)+1, len(args)+1)
-			copy(newElements, args[0].(*my_object.Array).Elements)
-			newElements[len(args)] = args[1]
+			// NOTE: a new array, the argument is left as it is
+			elements := args[0].(*my_object.Array).Elements
+			newElements := make([]my_object.Object, 0, len(elements)+len(args)-1)
+			newElements = append(newElements, elements...)
+			newElements = append(newElements, args[1:]...)
 			return &my_object.Array{Elements: newElements}
 		},
 	},
+	"keys": {
+		Fn: func(args ...my_object.Object) my_object.Object {
+			if len(args) != 1 {
+				return newError("wrong number of arguments: got=%d, want=1", len(args))
+			}
+			hash, ok := args[0].(*my_object.Hash)
+			if !ok {
+				return newError("argument to keys not supported: got %s", args[0].Type())
+			}
+			keys := make([]my_object.Object, 0, hash.Len())
+			for _, pair := range hash.Pairs() {
+				keys = append(keys, pair.Key)
+			}
+			return &my_object.Array{Elements: keys}
+		},
+	},
+	"values": {
+		Fn: func(args ...my_object.Object) my_object.Object {
+			if len(args) != 1 {
+				return newError("wrong number of arguments: got=%d, want=1", len(args))
+			}
+			hash, ok := args[0].(*my_object.Hash)
+			if !ok {
+				return newError("argument to values not supported: got %s", args[0].Type())
+			}
+			values := make([]my_object.Object, 0, hash.Len())
+			for _, pair := range hash.Pairs() {
+				values = append(values, pair.Value)
+			}
+			return &my_object.Array{Elements: values}
+		},
+	},
+	"freeze": {
+		Fn: func(args ...my_object.Object) my_object.Object {
+			if len(args) != 1 {
+				return newError("wrong number of arguments: got=%d, want=1", len(args))
+			}
+			return my_object.Freeze(args[0])
+		},
+	},
 	"put": {
 		Fn: func(args ...my_object.Object) my_object.Object {
 			fmt.Println()
@@ -0,0 +1,457 @@
+package my_evaluator
+
+import (
+	"monkey/my_ast"
+	"monkey/my_object"
+	"sort"
+	"strings"
+)
+
+// elementsArg: elements of the ARRAY or TUPLE argument at idx
+func elementsArg(name string, args []my_object.Object, idx int) ([]my_object.Object, *my_object.Error) {
+	switch arg := args[idx].(type) {
+	case *my_object.Array:
+		return arg.Elements, nil
+	case *my_object.Tuple:
+		return arg.Elements, nil
+	default:
+		return nil, argError(name, args, idx)
+	}
+}
+
+// functionArg: checks the argument at idx is a FUNCTION or BUILTIN
+func functionArg(name string, args []my_object.Object, idx int) *my_object.Error {
+	switch args[idx].(type) {
+	case *my_object.Function, *my_object.Builtin:
+		return nil
+	default:
+		return argError(name, args, idx)
+	}
+}
+
+// callFunction: calls back a monkey function from a builtin, NULL for no result
+func callFunction(fn my_object.Object, args ...my_object.Object) my_object.Object {
+	result := applyFunction(fn, args)
+	if result == nil {
+		return NULL
+	}
+	return result
+}
+
+// elementsFunc: a builtin taking a sequence and a function,
+// with fn seeing the elements and the function
+func elementsFunc(name string, fn func(elements []my_object.Object, f my_object.Object) my_object.Object) *my_object.Builtin {
+	return &my_object.Builtin{
+		Fn: func(args ...my_object.Object) my_object.Object {
+			if err := checkArgs(name, args, 2, argAny, argAny); err != nil {
+				return err
+			}
+			elements, err := elementsArg(name, args, 0)
+			if err != nil {
+				return err
+			}
+			if err := functionArg(name, args, 1); err != nil {
+				return err
+			}
+			return fn(elements, args[1])
+		},
+	}
+}
+
+// predicateFunc: a builtin taking a sequence and an optional predicate,
+// which defaults to the truthiness of the elements;
+// stop ends the iteration with the result found
+func predicateFunc(name string, stop bool) *my_object.Builtin {
+	return &my_object.Builtin{
+		Fn: func(args ...my_object.Object) my_object.Object {
+			if err := checkArgs(name, args, 1, argAny, argAny); err != nil {
+				return err
+			}
+			elements, err := elementsArg(name, args, 0)
+			if err != nil {
+				return err
+			}
+			if len(args) == 2 {
+				if err := functionArg(name, args, 1); err != nil {
+					return err
+				}
+			}
+			for _, elem := range elements {
+				result := elem
+				if len(args) == 2 {
+					result = callFunction(args[1], elem)
+				}
+				if isError(result) {
+					return result
+				}
+				if isTruthy(result) == stop {
+					return nativeBoolToBooleanObject(stop)
+				}
+			}
+			return nativeBoolToBooleanObject(!stop)
+		},
+	}
+}
+
+// extremeFunc: min or max of an array, or of the arguments if more than one
+func extremeFunc(name string, sign int) *my_object.Builtin {
+	return &my_object.Builtin{
+		Fn: func(args ...my_object.Object) my_object.Object {
+			if len(args) == 0 {
+				return newError("wrong number of arguments: got=0, want>=1")
+			}
+			elements := args
+			if len(args) == 1 {
+				var err *my_object.Error
+				if elements, err = elementsArg(name, args, 0); err != nil {
+					return err
+				}
+			}
+			return extremeOf(name, elements, sign)
+		},
+	}
+}
+
+// extremeOf: the smallest of elements if sign is -1, the largest if 1
+func extremeOf(name string, elements []my_object.Object, sign int) my_object.Object {
+	if len(elements) == 0 {
+		return newError("%s of empty array", name)
+	}
+	extreme := elements[0]
+	for _, elem := range elements[1:] {
+		cmp, err := compareObjects(elem, extreme)
+		if err != nil {
+			return newError("%s", err.Error())
+		}
+		if cmp*sign > 0 {
+			extreme = elem
+		}
+	}
+	return extreme
+}
+
+// isKeyFunction: whether by, the optional argument of sortBy, is a key function
+// taking one parameter rather than a comparator taking two;
+// builtins have no declared parameters and are always key functions, as in sortBy(a, len)
+func isKeyFunction(by my_object.Object) bool {
+	switch by := by.(type) {
+	case *my_object.Builtin:
+		return true
+	case *my_object.Function:
+		return len(by.Parameters) == 1
+	}
+	return false
+}
+
+// sortLess: a less function for sortBy comparing elements, or their keys,
+// as they are if by is nil, otherwise with the comparator by
+// which returns an INT (<0, 0 or >0) or a BOOLEAN (whether a < b);
+// the first error is kept in errp, and everything is equal afterwards
+func sortLess(by my_object.Object, errp *my_object.Object) func(a, b my_object.Object) bool {
+	if by == nil {
+		return func(a, b my_object.Object) bool {
+			cmp, err := compareObjects(a, b)
+			if err != nil {
+				*errp = newError("%s", err.Error())
+			}
+			return cmp < 0
+		}
+	}
+	return func(a, b my_object.Object) bool {
+		switch result := callFunction(by, a, b).(type) {
+		case *my_object.Integer:
+			return result.Value < 0
+		case *my_object.BigInteger:
+			return result.Value.Sign() < 0
+		case *my_object.Boolean:
+			return result.Value
+		case *my_object.Error:
+			*errp = result
+		default:
+			*errp = newError("sortBy comparator must return INT or BOOLEAN: got %s", result.Type())
+		}
+		return false
+	}
+}
+
+// flattenInto: appends elements to flat, flattening arrays and tuples depth levels deep
+func flattenInto(flat, elements []my_object.Object, depth int64) []my_object.Object {
+	for _, elem := range elements {
+		switch elem := elem.(type) {
+		case *my_object.Array:
+			if depth > 0 {
+				flat = flattenInto(flat, elem.Elements, depth-1)
+				continue
+			}
+		case *my_object.Tuple:
+			if depth > 0 {
+				flat = flattenInto(flat, elem.Elements, depth-1)
+				continue
+			}
+		}
+		flat = append(flat, elem)
+	}
+	return flat
+}
+
+// arrayBuiltins: collection functions, see init for their registration;
+// sequences are arrays or tuples, results are new arrays
+var arrayBuiltins = map[string]*my_object.Builtin{
+	"map": elementsFunc("map", func(elements []my_object.Object, fn my_object.Object) my_object.Object {
+		results := make([]my_object.Object, 0, len(elements))
+		for _, elem := range elements {
+			result := callFunction(fn, elem)
+			if isError(result) {
+				return result
+			}
+			results = append(results, result)
+		}
+		return &my_object.Array{Elements: results}
+	}),
+	"filter": elementsFunc("filter", func(elements []my_object.Object, fn my_object.Object) my_object.Object {
+		results := []my_object.Object{}
+		for _, elem := range elements {
+			result := callFunction(fn, elem)
+			if isError(result) {
+				return result
+			}
+			if isTruthy(result) {
+				results = append(results, elem)
+			}
+		}
+		return &my_object.Array{Elements: results}
+	}),
+	"each": elementsFunc("each", func(elements []my_object.Object, fn my_object.Object) my_object.Object {
+		for _, elem := range elements {
+			if result := callFunction(fn, elem); isError(result) {
+				return result
+			}
+		}
+		return NULL
+	}),
+	"find": elementsFunc("find", func(elements []my_object.Object, fn my_object.Object) my_object.Object {
+		for _, elem := range elements {
+			result := callFunction(fn, elem)
+			if isError(result) {
+				return result
+			}
+			if isTruthy(result) {
+				return elem
+			}
+		}
+		return NULL
+	}),
+	"any": predicateFunc("any", true),
+	"all": predicateFunc("all", false),
+	"reduce": {
+		Fn: func(args ...my_object.Object) my_object.Object {
+			if err := checkArgs("reduce", args, 2, argAny, argAny, argAny); err != nil {
+				return err
+			}
+			elements, err := elementsArg("reduce", args, 0)
+			if err != nil {
+				return err
+			}
+			if err := functionArg("reduce", args, 1); err != nil {
+				return err
+			}
+			if len(args) == 2 && len(elements) == 0 {
+				return newError("reduce of empty array with no initial value")
+			}
+			var acc my_object.Object
+			if len(args) == 3 {
+				acc = args[2]
+			} else {
+				acc, elements = elements[0], elements[1:]
+			}
+			for _, elem := range elements {
+				if acc = callFunction(args[1], acc, elem); isError(acc) {
+					return acc
+				}
+			}
+			return acc
+		},
+	},
+	"sortBy": {
+		Fn: func(args ...my_object.Object) my_object.Object {
+			if err := checkArgs("sortBy", args, 1, argAny, argAny); err != nil {
+				return err
+			}
+			elements, err := elementsArg("sortBy", args, 0)
+			if err != nil {
+				return err
+			}
+			var by my_object.Object
+			if len(args) == 2 {
+				if err := functionArg("sortBy", args, 1); err != nil {
+					return err
+				}
+				by = args[1]
+			}
+			// NOTE: keys are computed once per element, in order, and the elements
+			// are sorted by them through their indexes
+			keys := elements
+			if isKeyFunction(by) {
+				keys = make([]my_object.Object, len(elements))
+				for idx, elem := range elements {
+					if keys[idx] = callFunction(by, elem); isError(keys[idx]) {
+						return keys[idx]
+					}
+				}
+				by = nil
+			}
+			order := make([]int, len(elements))
+			for idx := range order {
+				order[idx] = idx
+			}
+			var sortErr my_object.Object
+			less := sortLess(by, &sortErr)
+			sort.SliceStable(order, func(i, j int) bool {
+				return sortErr == nil && less(keys[order[i]], keys[order[j]])
+			})
+			if sortErr != nil {
+				return sortErr
+			}
+			sorted := make([]my_object.Object, len(elements))
+			for idx, pos := range order {
+				sorted[idx] = elements[pos]
+			}
+			return &my_object.Array{Elements: sorted}
+		},
+	},
+	"zip": {
+		Fn: func(args ...my_object.Object) my_object.Object {
+			if len(args) == 0 {
+				return newError("wrong number of arguments: got=0, want>=1")
+			}
+			sequences := make([][]my_object.Object, len(args))
+			shortest := -1
+			for idx := range args {
+				elements, err := elementsArg("zip", args, idx)
+				if err != nil {
+					return err
+				}
+				sequences[idx] = elements
+				if shortest < 0 || len(elements) < shortest {
+					shortest = len(elements)
+				}
+			}
+			results := make([]my_object.Object, 0, shortest)
+			for pos := 0; pos < shortest; pos++ {
+				tuple := &my_object.Tuple{Elements: make([]my_object.Object, 0, len(sequences))}
+				for _, elements := range sequences {
+					tuple.Elements = append(tuple.Elements, elements[pos])
+				}
+				results = append(results, tuple)
+			}
+			return &my_object.Array{Elements: results}
+		},
+	},
+	"enumerate": {
+		Fn: func(args ...my_object.Object) my_object.Object {
+			if err := checkArgs("enumerate", args, 1, argAny); err != nil {
+				return err
+			}
+			elements, err := elementsArg("enumerate", args, 0)
+			if err != nil {
+				return err
+			}
+			results := make([]my_object.Object, 0, len(elements))
+			for idx, elem := range elements {
+				pair := []my_object.Object{&my_object.Integer{Value: int64(idx)}, elem}
+				results = append(results, &my_object.Tuple{Elements: pair})
+			}
+			return &my_object.Array{Elements: results}
+		},
+	},
+	"flatten": {
+		Fn: func(args ...my_object.Object) my_object.Object {
+			if err := checkArgs("flatten", args, 1, argAny, argInt); err != nil {
+				return err
+			}
+			elements, err := elementsArg("flatten", args, 0)
+			if err != nil {
+				return err
+			}
+			// NOTE: one level deep by default, a BigInteger depth flattens everything
+			depth := int64(1)
+			if len(args) == 2 {
+				if d, ok := args[1].(*my_object.Integer); ok {
+					depth = d.Value
+				} else {
+					depth = 1<<63 - 1
+				}
+			}
+			return &my_object.Array{Elements: flattenInto([]my_object.Object{}, elements, depth)}
+		},
+	},
+	"uniq": {
+		Fn: func(args ...my_object.Object) my_object.Object {
+			if err := checkArgs("uniq", args, 1, argAny); err != nil {
+				return err
+			}
+			elements, err := elementsArg("uniq", args, 0)
+			if err != nil {
+				return err
+			}
+			// NOTE: == equality, so 1 and 1.0 are the same, the first one is kept
+			results := []my_object.Object{}
+		next:
+			for _, elem := range elements {
+				for _, seen := range results {
+					if objectsEqual(seen, elem) {
+						continue next
+					}
+				}
+				results = append(results, elem)
+			}
+			return &my_object.Array{Elements: results}
+		},
+	},
+	"reverse": {
+		Fn: func(args ...my_object.Object) my_object.Object {
+			if err := checkArgs("reverse", args, 1, argAny); err != nil {
+				return err
+			}
+			if str, ok := args[0].(*my_object.String); ok {
+				chars := splitChars(str.Value)
+				for i, j := 0, len(chars)-1; i < j; i, j = i+1, j-1 {
+					chars[i], chars[j] = chars[j], chars[i]
+				}
+				return newString(strings.Join(chars, ""))
+			}
+			elements, err := elementsArg("reverse", args, 0)
+			if err != nil {
+				return err
+			}
+			results := make([]my_object.Object, len(elements))
+			for idx, elem := range elements {
+				results[len(elements)-1-idx] = elem
+			}
+			return &my_object.Array{Elements: results}
+		},
+	},
+	"sum": {
+		Fn: func(args ...my_object.Object) my_object.Object {
+			if err := checkArgs("sum", args, 1, argAny); err != nil {
+				return err
+			}
+			elements, err := elementsArg("sum", args, 0)
+			if err != nil {
+				return err
+			}
+			var total my_object.Object = &my_object.Integer{Value: 0}
+			for _, elem := range elements {
+				if !isNumeric(elem) {
+					return newError("sum expecting numbers, but got %s", elem.Type())
+				}
+				if total = evalInfixExpression(my_ast.INOP_PLUS, total, elem); isError(total) {
+					return total
+				}
+			}
+			return total
+		},
+	},
+	"min": extremeFunc("min", -1),
+	"max": extremeFunc("max", 1),
+}
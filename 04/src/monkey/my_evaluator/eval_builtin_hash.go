@@ -0,0 +1,136 @@
+package my_evaluator
+
+import "monkey/my_object"
+
+const argHash = my_object.HASH_OBJ
+
+// hashKey: key as a hash key, erring as hash literals do
+func hashKey(key my_object.Object) (my_object.HashableObject, *my_object.Error) {
+	hashable, ok := my_object.AsHashable(key)
+	if !ok {
+		return nil, newError("key type not hashable: %s", key.Type())
+	}
+	return hashable, nil
+}
+
+// hashKeyFunc: a builtin taking a hash and a key, with optional more arguments
+func hashKeyFunc(name string, fn func(args []my_object.Object, hash *my_object.Hash, key my_object.HashableObject) my_object.Object, more ...my_object.ObjectType) *my_object.Builtin {
+	types := append([]my_object.ObjectType{argHash, argAny}, more...)
+	return &my_object.Builtin{
+		Fn: func(args ...my_object.Object) my_object.Object {
+			if err := checkArgs(name, args, 2, types...); err != nil {
+				return err
+			}
+			key, err := hashKey(args[1])
+			if err != nil {
+				return err
+			}
+			return fn(args, args[0].(*my_object.Hash), key)
+		},
+	}
+}
+
+// pairElements: key and value of a pair given as a tuple or an array of two
+func pairElements(pair my_object.Object) ([]my_object.Object, bool) {
+	switch pair := pair.(type) {
+	case *my_object.Tuple:
+		return pair.Elements, len(pair.Elements) == 2
+	case *my_object.Array:
+		return pair.Elements, len(pair.Elements) == 2
+	}
+	return nil, false
+}
+
+// hashBuiltins: hash functions, see init for their registration;
+// pairs are iterated in insertion order
+var hashBuiltins = map[string]*my_object.Builtin{
+	"items": {
+		Fn: func(args ...my_object.Object) my_object.Object {
+			if err := checkArgs("items", args, 1, argHash); err != nil {
+				return err
+			}
+			hash := args[0].(*my_object.Hash)
+			items := make([]my_object.Object, 0, hash.Len())
+			for _, pair := range hash.Pairs() {
+				items = append(items, &my_object.Tuple{Elements: []my_object.Object{pair.Key, pair.Value}})
+			}
+			return &my_object.Array{Elements: items}
+		},
+	},
+	"size": {
+		Fn: func(args ...my_object.Object) my_object.Object {
+			if err := checkArgs("size", args, 1, argHash); err != nil {
+				return err
+			}
+			return &my_object.Integer{Value: int64(args[0].(*my_object.Hash).Len())}
+		},
+	},
+	"has": hashKeyFunc("has", func(args []my_object.Object, hash *my_object.Hash, key my_object.HashableObject) my_object.Object {
+		_, ok := hash.Get(key)
+		return nativeBoolToBooleanObject(ok)
+	}),
+	"get": hashKeyFunc("get", func(args []my_object.Object, hash *my_object.Hash, key my_object.HashableObject) my_object.Object {
+		if pair, ok := hash.Get(key); ok {
+			return pair.Value
+		}
+		if len(args) == 3 {
+			return args[2]
+		}
+		return NULL
+	}, argAny),
+	// NOTE: in place, whether the key was there
+	"delete": hashKeyFunc("delete", func(args []my_object.Object, hash *my_object.Hash, key my_object.HashableObject) my_object.Object {
+		deleted, err := hash.Delete(key)
+		if err != nil {
+			return newError("%s", err.Error())
+		}
+		return nativeBoolToBooleanObject(deleted)
+	}),
+	// NOTE: a new hash, values of later hashes win
+	"merge": {
+		Fn: func(args ...my_object.Object) my_object.Object {
+			if len(args) == 0 {
+				return newError("wrong number of arguments: got=0, want>=1")
+			}
+			merged := my_object.NewHash()
+			for idx, arg := range args {
+				hash, ok := arg.(*my_object.Hash)
+				if !ok {
+					return argError("merge", args, idx)
+				}
+				for _, pair := range hash.Pairs() {
+					merged.Set(pair.Key.(my_object.HashableObject), pair.Value)
+				}
+			}
+			return merged
+		},
+	},
+	// hash: a hash from an array of (key, value) pairs, later pairs win
+	"hash": {
+		Fn: func(args ...my_object.Object) my_object.Object {
+			if err := checkArgs("hash", args, 0, argAny); err != nil {
+				return err
+			}
+			hash := my_object.NewHash()
+			if len(args) == 0 {
+				return hash
+			}
+			elements, err := elementsArg("hash", args, 0)
+			if err != nil {
+				return err
+			}
+			for _, elem := range elements {
+				pair, ok := pairElements(elem)
+				if !ok {
+					return newError("hash expecting pairs of key and value, but got %s", elem.Inspect())
+				}
+				key, err := hashKey(pair[0])
+				if err != nil {
+					return err
+				}
+				hash.Set(key, pair[1])
+			}
+			return hash
+		},
+	},
+}
@@ -0,0 +1,227 @@
+package my_evaluator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"monkey/my_object"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// decodeJSON: the next JSON value of dec as an object;
+// objects become hashes keeping the order of their keys,
+// numbers become INT if written without fraction or exponent, FLOAT otherwise
+func decodeJSON(dec *json.Decoder) (my_object.Object, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch tok := tok.(type) {
+	case json.Delim:
+		if tok == '[' {
+			elements := []my_object.Object{}
+			for dec.More() {
+				elem, err := decodeJSON(dec)
+				if err != nil {
+					return nil, err
+				}
+				elements = append(elements, elem)
+			}
+			_, err := dec.Token()
+			return &my_object.Array{Elements: elements}, err
+		}
+		hash := my_object.NewHash()
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeJSON(dec)
+			if err != nil {
+				return nil, err
+			}
+			hash.Set(&my_object.String{Value: key.(string)}, value)
+		}
+		_, err := dec.Token()
+		return hash, err
+	case string:
+		return newString(tok), nil
+	case json.Number:
+		if !strings.ContainsAny(string(tok), ".eE") {
+			if i, ok := new(big.Int).SetString(string(tok), 10); ok {
+				return bigToIntegerObject(i), nil
+			}
+		}
+		f, err := strconv.ParseFloat(string(tok), 64)
+		if err != nil {
+			return nil, fmt.Errorf("number out of range: %s", tok)
+		}
+		return &my_object.Float{Value: f}, nil
+	case bool:
+		return nativeBoolToBooleanObject(tok), nil
+	default:
+		return NULL, nil
+	}
+}
+
+// jsonEncoder: writes objects as compact JSON,
+// remembering the containers being written to detect cycles
+type jsonEncoder struct {
+	buf  bytes.Buffer
+	seen map[my_object.Object]bool
+}
+
+func (e *jsonEncoder) encode(obj my_object.Object) error {
+	switch obj := obj.(type) {
+	case *my_object.Null:
+		e.buf.WriteString("null")
+	case *my_object.Boolean:
+		e.buf.WriteString(strconv.FormatBool(obj.Value))
+	case *my_object.Integer:
+		e.buf.WriteString(strconv.FormatInt(obj.Value, 10))
+	case *my_object.BigInteger:
+		e.buf.WriteString(obj.Value.String())
+	case *my_object.Float:
+		if !my_object.IsFiniteFloat(obj.Value) {
+			return fmt.Errorf("cannot encode %s", obj.String())
+		}
+		f, _ := json.Marshal(obj.Value)
+		e.buf.Write(f)
+		// NOTE: so that it is parsed back as a FLOAT
+		if !bytes.ContainsAny(f, ".eE") {
+			e.buf.WriteString(".0")
+		}
+	case *my_object.String:
+		return e.encodeString(obj.Value)
+	case *my_object.Array:
+		return e.encodeElements(obj, obj.Elements)
+	case *my_object.Tuple:
+		return e.encodeElements(obj, obj.Elements)
+	case *my_object.Hash:
+		if e.seen[obj] {
+			return fmt.Errorf("cannot encode cyclic structure")
+		}
+		e.seen[obj] = true
+		defer delete(e.seen, obj)
+		e.buf.WriteByte('{')
+		for idx, pair := range obj.Pairs() {
+			key, ok := pair.Key.(*my_object.String)
+			if !ok {
+				return fmt.Errorf("cannot encode hash key of type %s", pair.Key.Type())
+			}
+			if idx > 0 {
+				e.buf.WriteByte(',')
+			}
+			if err := e.encodeString(key.Value); err != nil {
+				return err
+			}
+			e.buf.WriteByte(':')
+			if err := e.encode(pair.Value); err != nil {
+				return err
+			}
+		}
+		e.buf.WriteByte('}')
+	default:
+		return fmt.Errorf("cannot encode %s", obj.Type())
+	}
+	return nil
+}
+
+func (e *jsonEncoder) encodeElements(container my_object.Object, elements []my_object.Object) error {
+	if e.seen[container] {
+		return fmt.Errorf("cannot encode cyclic structure")
+	}
+	e.seen[container] = true
+	defer delete(e.seen, container)
+	e.buf.WriteByte('[')
+	for idx, elem := range elements {
+		if idx > 0 {
+			e.buf.WriteByte(',')
+		}
+		if err := e.encode(elem); err != nil {
+			return err
+		}
+	}
+	e.buf.WriteByte(']')
+	return nil
+}
+
+// encodeString: s quoted, leaving <, > and & as they are;
+// invalid UTF-8, as made by "\xff", is an error rather than replaced
+func (e *jsonEncoder) encodeString(s string) error {
+	if !utf8.ValidString(s) {
+		return fmt.Errorf("cannot encode invalid UTF-8 in %s", (&my_object.String{Value: s}).Inspect())
+	}
+	enc := json.NewEncoder(&e.buf)
+	enc.SetEscapeHTML(false)
+	enc.Encode(s)
+	e.buf.Truncate(e.buf.Len() - 1) // newline added by Encode
+	return nil
+}
+
+// jsonMembers: the json module, see init for its registration
+var jsonMembers = map[string]my_object.Object{
+	"parse": &my_object.Builtin{
+		Fn: func(args ...my_object.Object) my_object.Object {
+			if err := checkArgs("json.parse", args, 1, argString); err != nil {
+				return err
+			}
+			dec := json.NewDecoder(strings.NewReader(args[0].(*my_object.String).Value))
+			dec.UseNumber()
+			obj, err := decodeJSON(dec)
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			if err == nil {
+				if _, trailing := dec.Token(); trailing != io.EOF {
+					err = fmt.Errorf("invalid data after top-level value")
+				}
+			}
+			if err != nil {
+				return newError("json.parse: %s", err.Error())
+			}
+			return obj
+		},
+	},
+	// NOTE: indent is a number of spaces or a string of spaces and tabs, compact if missing
+	"stringify": &my_object.Builtin{
+		Fn: func(args ...my_object.Object) my_object.Object {
+			if err := checkArgs("json.stringify", args, 1, argAny, argAny); err != nil {
+				return err
+			}
+			indent := ""
+			if len(args) == 2 {
+				switch arg := args[1].(type) {
+				case *my_object.String:
+					if strings.Trim(arg.Value, " \t") != "" {
+						return newError("json.stringify expecting indent of spaces and tabs, but got %s", arg.Inspect())
+					}
+					indent = arg.Value
+				case *my_object.Integer:
+					if arg.Value < 0 || arg.Value > 10 {
+						return newError("json.stringify expecting indent in 0..10, but got %d", arg.Value)
+					}
+					indent = strings.Repeat(" ", int(arg.Value))
+				default:
+					return argError("json.stringify", args, 1)
+				}
+			}
+			e := &jsonEncoder{seen: map[my_object.Object]bool{}}
+			if err := e.encode(args[0]); err != nil {
+				return newError("json.stringify: %s", err.Error())
+			}
+			if indent == "" {
+				return newString(e.buf.String())
+			}
+			indented := &bytes.Buffer{}
+			if err := json.Indent(indented, e.buf.Bytes(), "", indent); err != nil {
+				return newError("json.stringify: %s", err.Error())
+			}
+			return newString(indented.String())
+		},
+	},
+}
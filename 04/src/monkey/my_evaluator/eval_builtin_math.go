@@ -0,0 +1,233 @@
+package my_evaluator
+
+import (
+	"math"
+	"math/big"
+	"monkey/my_object"
+)
+
+// maxPowBits: bound on the size of exact integer powers
+const maxPowBits = 1 << 20
+
+// floatArg: the INT, FLOAT or BOOLEAN argument at idx as a float
+func floatArg(name string, args []my_object.Object, idx int) (float64, *my_object.Error) {
+	switch arg := args[idx].(type) {
+	case *my_object.Float:
+		return arg.Value, nil
+	case *my_object.Integer:
+		return integerToFloatObject(arg).Value, nil
+	case *my_object.BigInteger:
+		return bigIntegerToFloatObject(arg).Value, nil
+	case *my_object.Boolean:
+		return booleanToFloatObject(arg).Value, nil
+	default:
+		return 0, argError(name, args, idx)
+	}
+}
+
+// floatResult: result of the math function name following the FloatMode,
+// where turning finite arguments into inf or nan is an error if strict
+func floatResult(name string, result float64, args ...float64) my_object.Object {
+	if GetFloatMode() == FloatStrict && !my_object.IsFiniteFloat(result) {
+		for _, arg := range args {
+			if !my_object.IsFiniteFloat(arg) {
+				return &my_object.Float{Value: result}
+			}
+		}
+		return newError("%s: result not finite: %s", name, (&my_object.Float{Value: result}).String())
+	}
+	return &my_object.Float{Value: result}
+}
+
+// floatToIntegerObject: f truncated to an INT, promoted to BigInteger if needed
+func floatToIntegerObject(f float64) my_object.Object {
+	if !my_object.IsFiniteFloat(f) {
+		return newError("cannot convert %s to INT", (&my_object.Float{Value: f}).String())
+	}
+	i, _ := big.NewFloat(f).Int(nil)
+	return bigToIntegerObject(i)
+}
+
+// unaryMathFunc: a math function of one number giving a FLOAT
+func unaryMathFunc(name string, fn func(float64) float64) *my_object.Builtin {
+	return &my_object.Builtin{
+		Fn: func(args ...my_object.Object) my_object.Object {
+			if err := checkArgs(name, args, 1, argAny); err != nil {
+				return err
+			}
+			x, err := floatArg(name, args, 0)
+			if err != nil {
+				return err
+			}
+			return floatResult(name, fn(x), x)
+		},
+	}
+}
+
+// roundingMathFunc: a math function rounding a number to an INT;
+// integers are returned as they are
+func roundingMathFunc(name string, fn func(float64) float64) *my_object.Builtin {
+	return &my_object.Builtin{
+		Fn: func(args ...my_object.Object) my_object.Object {
+			if err := checkArgs(name, args, 1, argAny); err != nil {
+				return err
+			}
+			switch arg := args[0].(type) {
+			case *my_object.Integer, *my_object.BigInteger:
+				return arg
+			}
+			x, err := floatArg(name, args, 0)
+			if err != nil {
+				return err
+			}
+			return floatToIntegerObject(fn(x))
+		},
+	}
+}
+
+// binaryMathFunc: a math function of two numbers giving a FLOAT
+func binaryMathFunc(name string, fn func(float64, float64) float64) *my_object.Builtin {
+	return &my_object.Builtin{
+		Fn: func(args ...my_object.Object) my_object.Object {
+			if err := checkArgs(name, args, 2, argAny, argAny); err != nil {
+				return err
+			}
+			x, err := floatArg(name, args, 0)
+			if err != nil {
+				return err
+			}
+			y, err := floatArg(name, args, 1)
+			if err != nil {
+				return err
+			}
+			return floatResult(name, fn(x, y), x, y)
+		},
+	}
+}
+
+// integerArg: the INT argument at idx as a big.Int, false if it is not an integer
+func integerArg(args []my_object.Object, idx int) (*big.Int, bool) {
+	switch arg := args[idx].(type) {
+	case *my_object.Integer:
+		return big.NewInt(arg.Value), true
+	case *my_object.BigInteger:
+		return arg.Value, true
+	}
+	return nil, false
+}
+
+// numberArg: obj as a number keeping integers exact, booleans as 0 or 1,
+// false if it is not a number
+func numberArg(obj my_object.Object) (my_object.Object, bool) {
+	switch obj := obj.(type) {
+	case *my_object.Integer, *my_object.BigInteger, *my_object.Float:
+		return obj, true
+	case *my_object.Boolean:
+		return booleanToIntObject(obj), true
+	}
+	return nil, false
+}
+
+// numericExtremeFunc: min or max of numbers given as arguments or as one array
+func numericExtremeFunc(name string, sign int) *my_object.Builtin {
+	return &my_object.Builtin{
+		Fn: func(args ...my_object.Object) my_object.Object {
+			if len(args) == 0 {
+				return newError("wrong number of arguments: got=0, want>=1")
+			}
+			elements := args
+			if len(args) == 1 {
+				var err *my_object.Error
+				if elements, err = elementsArg(name, args, 0); err != nil {
+					return err
+				}
+			}
+			numbers := make([]my_object.Object, 0, len(elements))
+			for idx, elem := range elements {
+				number, ok := numberArg(elem)
+				if !ok {
+					if len(args) == 1 {
+						return newError("%s expecting numbers, but got %s", name, elem.Type())
+					}
+					return argError(name, args, idx)
+				}
+				numbers = append(numbers, number)
+			}
+			return extremeOf(name, numbers, sign)
+		},
+	}
+}
+
+// mathMembers: the math module, see init for its registration
+var mathMembers = map[string]my_object.Object{
+	"pi":  &my_object.Float{Value: math.Pi},
+	"e":   &my_object.Float{Value: math.E},
+	"inf": &my_object.Float{Value: math.Inf(1)},
+	"nan": &my_object.Float{Value: math.NaN()},
+
+	"sqrt":  unaryMathFunc("math.sqrt", math.Sqrt),
+	"log":   unaryMathFunc("math.log", math.Log),
+	"log2":  unaryMathFunc("math.log2", math.Log2),
+	"log10": unaryMathFunc("math.log10", math.Log10),
+	"exp":   unaryMathFunc("math.exp", math.Exp),
+	"sin":   unaryMathFunc("math.sin", math.Sin),
+	"cos":   unaryMathFunc("math.cos", math.Cos),
+	"tan":   unaryMathFunc("math.tan", math.Tan),
+	"asin":  unaryMathFunc("math.asin", math.Asin),
+	"acos":  unaryMathFunc("math.acos", math.Acos),
+	"atan":  unaryMathFunc("math.atan", math.Atan),
+	"atan2": binaryMathFunc("math.atan2", math.Atan2),
+	"hypot": binaryMathFunc("math.hypot", math.Hypot),
+
+	"floor": roundingMathFunc("math.floor", math.Floor),
+	"ceil":  roundingMathFunc("math.ceil", math.Ceil),
+	"round": roundingMathFunc("math.round", math.Round),
+	"trunc": roundingMathFunc("math.trunc", math.Trunc),
+
+	"min": numericExtremeFunc("math.min", -1),
+	"max": numericExtremeFunc("math.max", 1),
+
+	"abs": &my_object.Builtin{
+		Fn: func(args ...my_object.Object) my_object.Object {
+			if err := checkArgs("math.abs", args, 1, argAny); err != nil {
+				return err
+			}
+			if b, ok := args[0].(*my_object.Boolean); ok {
+				return booleanToIntObject(b)
+			}
+			if i, ok := integerArg(args, 0); ok {
+				return bigToIntegerObject(new(big.Int).Abs(i))
+			}
+			x, err := floatArg("math.abs", args, 0)
+			if err != nil {
+				return err
+			}
+			return &my_object.Float{Value: math.Abs(x)}
+		},
+	},
+	// NOTE: exact for integer powers of integers, like * is
+	"pow": &my_object.Builtin{
+		Fn: func(args ...my_object.Object) my_object.Object {
+			if err := checkArgs("math.pow", args, 2, argAny, argAny); err != nil {
+				return err
+			}
+			base, bok := integerArg(args, 0)
+			exp, eok := integerArg(args, 1)
+			if bok && eok && exp.Sign() >= 0 {
+				if base.CmpAbs(big.NewInt(1)) > 0 && (!exp.IsInt64() || exp.Int64() > maxPowBits/int64(base.BitLen())) {
+					return newError("math.pow: result too large: %s**%s", base, exp)
+				}
+				return bigToIntegerObject(new(big.Int).Exp(base, exp, nil))
+			}
+			x, err := floatArg("math.pow", args, 0)
+			if err != nil {
+				return err
+			}
+			y, err := floatArg("math.pow", args, 1)
+			if err != nil {
+				return err
+			}
+			return floatResult("math.pow", math.Pow(x, y), x, y)
+		},
+	},
+}
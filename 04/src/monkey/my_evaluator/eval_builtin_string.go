@@ -0,0 +1,311 @@
+package my_evaluator
+
+import (
+	"fmt"
+	"math"
+	"monkey/my_object"
+	"strings"
+	"unicode/utf8"
+)
+
+// argument types checked by checkArgs
+const (
+	argString = my_object.STRING_OBJ
+	argInt    = my_object.INTEGER_OBJ
+	argArray  = my_object.ARRAY_OBJ
+	argAny    = my_object.ObjectType("")
+)
+
+// checkArgs: checks the arity and the argument types of the builtin name,
+// the types after the first required ones are optional, argAny accepts anything;
+// errors are worded as the ones of len
+func checkArgs(name string, args []my_object.Object, required int, types ...my_object.ObjectType) *my_object.Error {
+	if len(args) < required || len(args) > len(types) {
+		if required == len(types) {
+			return newError("wrong number of arguments: got=%d, want=%d", len(args), required)
+		}
+		return newError("wrong number of arguments: got=%d, want=%d..%d", len(args), required, len(types))
+	}
+	for idx, arg := range args {
+		if types[idx] != argAny && arg.Type() != types[idx] {
+			return argError(name, args, idx)
+		}
+	}
+	return nil
+}
+
+// argError: the argument at idx is not supported by the builtin name
+func argError(name string, args []my_object.Object, idx int) *my_object.Error {
+	if len(args) == 1 {
+		return newError("argument to %s not supported: got %s", name, args[idx].Type())
+	}
+	return newError("argument %d to %s not supported: got %s", idx+1, name, args[idx].Type())
+}
+
+// stringFunc: a builtin taking a single string
+func stringFunc(name string, fn func(s string) my_object.Object) *my_object.Builtin {
+	return &my_object.Builtin{
+		Fn: func(args ...my_object.Object) my_object.Object {
+			if err := checkArgs(name, args, 1, argString); err != nil {
+				return err
+			}
+			return fn(args[0].(*my_object.String).Value)
+		},
+	}
+}
+
+// stringsFunc: a builtin taking count strings
+func stringsFunc(name string, fn func(s []string) my_object.Object, count int) *my_object.Builtin {
+	types := make([]my_object.ObjectType, count)
+	for idx := range types {
+		types[idx] = argString
+	}
+	return &my_object.Builtin{
+		Fn: func(args ...my_object.Object) my_object.Object {
+			if err := checkArgs(name, args, count, types...); err != nil {
+				return err
+			}
+			values := make([]string, count)
+			for idx, arg := range args {
+				values[idx] = arg.(*my_object.String).Value
+			}
+			return fn(values)
+		},
+	}
+}
+
+func newString(s string) my_object.Object {
+	return &my_object.String{Value: s}
+}
+
+// splitChars: s split into the characters it is indexed by, one per rune;
+// a byte that is not valid UTF-8 stays a character of its own,
+// as counted by len, rather than becoming U+FFFD
+func splitChars(s string) []string {
+	chars := make([]string, 0, len(s))
+	for len(s) > 0 {
+		_, size := utf8.DecodeRuneInString(s)
+		chars = append(chars, s[:size])
+		s = s[size:]
+	}
+	return chars
+}
+
+// stringsToArray: an argArray of STRING
+func stringsToArray(values []string) *my_object.Array {
+	elements := make([]my_object.Object, 0, len(values))
+	for _, value := range values {
+		elements = append(elements, newString(value))
+	}
+	return &my_object.Array{Elements: elements}
+}
+
+// trimFunc: trims whitespace, or the characters in the optional second argument
+func trimFunc(name string, trim func(s, cutset string) string) *my_object.Builtin {
+	return &my_object.Builtin{
+		Fn: func(args ...my_object.Object) my_object.Object {
+			if err := checkArgs(name, args, 1, argString, argString); err != nil {
+				return err
+			}
+			cutset := " \t\r\n\v\f"
+			if len(args) == 2 {
+				cutset = args[1].(*my_object.String).Value
+			}
+			return newString(trim(args[0].(*my_object.String).Value, cutset))
+		},
+	}
+}
+
+// padFunc: pads a string to a width in runes with spaces,
+// or with the single character in the optional third argument
+func padFunc(name string, left bool) *my_object.Builtin {
+	return &my_object.Builtin{
+		Fn: func(args ...my_object.Object) my_object.Object {
+			if err := checkArgs(name, args, 2, argString, argInt, argString); err != nil {
+				return err
+			}
+			s := args[0].(*my_object.String).Value
+			width, ok := args[1].(*my_object.Integer)
+			if !ok {
+				return newError("%s width too large: %s", name, args[1].Inspect())
+			}
+			pad := " "
+			if len(args) == 3 {
+				pad = args[2].(*my_object.String).Value
+				if utf8.RuneCountInString(pad) != 1 {
+					return newError("%s expecting a single character to pad with, but got %s", name, args[2].Inspect())
+				}
+			}
+			count := width.Value - int64(utf8.RuneCountInString(s))
+			if count <= 0 {
+				return newString(s)
+			}
+			if count > math.MaxInt32 {
+				return newError("%s width too large: %d", name, width.Value)
+			}
+			padding := strings.Repeat(pad, int(count))
+			if left {
+				return newString(padding + s)
+			}
+			return newString(s + padding)
+		},
+	}
+}
+
+// formatVerbs: the verbs of the printf-style format, one per argument;
+// flags, width and precision are allowed, %% is a literal percent sign,
+// but * and explicit argument indexes are not
+func formatVerbs(format string) ([]byte, error) {
+	verbs := []byte{}
+	for idx := 0; idx < len(format); idx++ {
+		if format[idx] != '%' {
+			continue
+		}
+		idx++
+		for idx < len(format) && strings.IndexByte("+-# 0123456789.", format[idx]) >= 0 {
+			idx++
+		}
+		if idx >= len(format) {
+			return nil, fmt.Errorf("format: missing verb at end of %q", format)
+		}
+		switch verb := format[idx]; {
+		case verb == '%':
+		case strings.IndexByte("vdboxXcsqtfFeEgG", verb) >= 0:
+			verbs = append(verbs, verb)
+		default:
+			return nil, fmt.Errorf("format: unsupported verb %%%c", verb)
+		}
+	}
+	return verbs, nil
+}
+
+// formatArg: the go value formatted for obj by verb, false if the verb does not fit;
+// %v formats anything in its display form, %s and %q strings,
+// %t booleans, %f and friends numbers, the other verbs integers,
+// and %x or %X strings as well
+func formatArg(verb byte, obj my_object.Object) (interface{}, bool) {
+	switch obj := obj.(type) {
+	case *my_object.Integer:
+		if strings.IndexByte("fFeEgG", verb) >= 0 {
+			return float64(obj.Value), true
+		}
+		return obj.Value, strings.IndexByte("vdboxXc", verb) >= 0
+	case *my_object.BigInteger:
+		if strings.IndexByte("fFeEgG", verb) >= 0 {
+			return bigIntegerToFloatObject(obj).Value, true
+		}
+		return obj.Value, strings.IndexByte("vdboxX", verb) >= 0
+	case *my_object.Float:
+		return obj.Value, strings.IndexByte("vfFeEgG", verb) >= 0
+	case *my_object.Boolean:
+		return obj.Value, verb == 'v' || verb == 't'
+	case *my_object.String:
+		return obj.Value, strings.IndexByte("vsqxX", verb) >= 0
+	default:
+		return obj.String(), verb == 'v'
+	}
+}
+
+// stringBuiltins: string functions, see init for their registration
+var stringBuiltins = map[string]*my_object.Builtin{
+	"split": stringsFunc("split", func(s []string) my_object.Object {
+		return stringsToArray(strings.Split(s[0], s[1]))
+	}, 2),
+	"join": {
+		Fn: func(args ...my_object.Object) my_object.Object {
+			if err := checkArgs("join", args, 1, argAny, argString); err != nil {
+				return err
+			}
+			elements, err := elementsArg("join", args, 0)
+			if err != nil {
+				return err
+			}
+			sep := ""
+			if len(args) == 2 {
+				sep = args[1].(*my_object.String).Value
+			}
+			parts := make([]string, 0, len(elements))
+			for _, elem := range elements {
+				parts = append(parts, elem.String())
+			}
+			return newString(strings.Join(parts, sep))
+		},
+	},
+	"trim":      trimFunc("trim", strings.Trim),
+	"trimLeft":  trimFunc("trimLeft", strings.TrimLeft),
+	"trimRight": trimFunc("trimRight", strings.TrimRight),
+	"upper": stringFunc("upper", func(s string) my_object.Object {
+		return newString(strings.ToUpper(s))
+	}),
+	"lower": stringFunc("lower", func(s string) my_object.Object {
+		return newString(strings.ToLower(s))
+	}),
+	"replace": stringsFunc("replace", func(s []string) my_object.Object {
+		return newString(strings.ReplaceAll(s[0], s[1], s[2]))
+	}, 3),
+	"contains": stringsFunc("contains", func(s []string) my_object.Object {
+		return nativeBoolToBooleanObject(strings.Contains(s[0], s[1]))
+	}, 2),
+	"startsWith": stringsFunc("startsWith", func(s []string) my_object.Object {
+		return nativeBoolToBooleanObject(strings.HasPrefix(s[0], s[1]))
+	}, 2),
+	"endsWith": stringsFunc("endsWith", func(s []string) my_object.Object {
+		return nativeBoolToBooleanObject(strings.HasSuffix(s[0], s[1]))
+	}, 2),
+	// NOTE: in runes, as strings are indexed
+	"indexOf": stringsFunc("indexOf", func(s []string) my_object.Object {
+		idx := strings.Index(s[0], s[1])
+		if idx < 0 {
+			return &my_object.Integer{Value: -1}
+		}
+		return &my_object.Integer{Value: int64(utf8.RuneCountInString(s[0][:idx]))}
+	}, 2),
+	"repeat": {
+		Fn: func(args ...my_object.Object) my_object.Object {
+			if err := checkArgs("repeat", args, 2, argString, argInt); err != nil {
+				return err
+			}
+			s := args[0].(*my_object.String).Value
+			count, ok := args[1].(*my_object.Integer)
+			if ok && count.Value < 0 {
+				return newError("repeat expecting non-negative count, but got %d", count.Value)
+			}
+			if !ok || len(s) > 0 && count.Value > math.MaxInt32/int64(len(s)) {
+				return newError("repeat result too large: %s", args[1].Inspect())
+			}
+			return newString(strings.Repeat(s, int(count.Value)))
+		},
+	},
+	"padLeft":  padFunc("padLeft", true),
+	"padRight": padFunc("padRight", false),
+	"chars": stringFunc("chars", func(s string) my_object.Object {
+		return stringsToArray(splitChars(s))
+	}),
+	"format": {
+		Fn: func(args ...my_object.Object) my_object.Object {
+			if len(args) < 1 {
+				return newError("wrong number of arguments: got=%d, want>=1", len(args))
+			}
+			format, ok := args[0].(*my_object.String)
+			if !ok {
+				return newError("argument 1 to format not supported: got %s", args[0].Type())
+			}
+			verbs, err := formatVerbs(format.Value)
+			if err != nil {
+				return newError("%s", err.Error())
+			}
+			if len(args) != len(verbs)+1 {
+				return newError("wrong number of arguments: got=%d, want=%d", len(args), len(verbs)+1)
+			}
+			values := make([]interface{}, 0, len(verbs))
+			for idx, verb := range verbs {
+				value, ok := formatArg(verb, args[idx+1])
+				if !ok {
+					return argError("format", args, idx+1)
+				}
+				values = append(values, value)
+			}
+			return newString(fmt.Sprintf(format.Value, values...))
+		},
+	},
+}
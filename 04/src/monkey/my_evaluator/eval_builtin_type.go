@@ -0,0 +1,149 @@
+package my_evaluator
+
+import (
+	"math/big"
+	"monkey/my_object"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// parseInteger: s as an integer literal with an optional sign,
+// decimal, or hex, octal or binary with 0x, 0o or 0b
+func parseInteger(s string) (*big.Int, bool) {
+	digits := strings.TrimLeft(s, "+-")
+	if len(s)-len(digits) > 1 {
+		return nil, false
+	}
+	base := 10
+	if len(digits) > 1 && digits[0] == '0' && strings.ContainsRune("xXoObB", rune(digits[1])) {
+		base = 0
+	}
+	return new(big.Int).SetString(s, base)
+}
+
+// floatPattern: decimal float literals as the lexer reads them, with an optional sign;
+// no underscores, hex mantissas or words like inf and nan
+var floatPattern = regexp.MustCompile(`^[+-]?([0-9]+(\.[0-9]+)?|\.[0-9]+)([eE][+-]?[0-9]+)?$`)
+
+// parseFloat: s as a float literal with an optional sign, false if malformed or out of range
+func parseFloat(s string) (float64, bool) {
+	if !floatPattern.MatchString(s) {
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	return f, err == nil
+}
+
+func conversionError(obj my_object.Object, target my_object.ObjectType) *my_object.Error {
+	if _, ok := obj.(*my_object.String); ok {
+		return newError("cannot convert %s to %s", obj.Inspect(), target)
+	}
+	return newError("cannot convert %s to %s", obj.Type(), target)
+}
+
+// conversionFunc: a builtin converting its only argument
+func conversionFunc(name string, convert func(obj my_object.Object) my_object.Object) *my_object.Builtin {
+	return &my_object.Builtin{
+		Fn: func(args ...my_object.Object) my_object.Object {
+			if err := checkArgs(name, args, 1, argAny); err != nil {
+				return err
+			}
+			return convert(args[0])
+		},
+	}
+}
+
+// predicateOf: a builtin telling whether its only argument is of one of types
+func predicateOf(name string, types ...my_object.ObjectType) *my_object.Builtin {
+	return conversionFunc(name, func(obj my_object.Object) my_object.Object {
+		for _, t := range types {
+			if obj.Type() == t {
+				return TRUE
+			}
+		}
+		return FALSE
+	})
+}
+
+// typeBuiltins: conversion and introspection functions, see init for their registration;
+// strings are parsed strictly, without surrounding spaces
+var typeBuiltins = map[string]*my_object.Builtin{
+	// NOTE: floats are truncated toward zero
+	"int": conversionFunc("int", func(obj my_object.Object) my_object.Object {
+		switch obj := obj.(type) {
+		case *my_object.Integer, *my_object.BigInteger:
+			return obj
+		case *my_object.Float:
+			return floatToIntegerObject(obj.Value)
+		case *my_object.Boolean:
+			return booleanToIntObject(obj)
+		case *my_object.String:
+			if i, ok := parseInteger(obj.Value); ok {
+				return bigToIntegerObject(i)
+			}
+		}
+		return conversionError(obj, my_object.INTEGER_OBJ)
+	}),
+	"float": conversionFunc("float", func(obj my_object.Object) my_object.Object {
+		switch obj := obj.(type) {
+		case *my_object.Float:
+			return obj
+		case *my_object.Integer:
+			return integerToFloatObject(obj)
+		case *my_object.BigInteger:
+			return bigIntegerToFloatObject(obj)
+		case *my_object.Boolean:
+			return booleanToFloatObject(obj)
+		case *my_object.String:
+			if f, ok := parseFloat(obj.Value); ok {
+				return &my_object.Float{Value: f}
+			}
+		}
+		return conversionError(obj, my_object.FLOAT_OBJ)
+	}),
+	// NOTE: the display form, as in string interpolation
+	"str": conversionFunc("str", func(obj my_object.Object) my_object.Object {
+		if str, ok := obj.(*my_object.String); ok {
+			return str
+		}
+		return newString(obj.String())
+	}),
+	// NOTE: numbers are true unless zero, strings must be "true" or "false"
+	"bool": conversionFunc("bool", func(obj my_object.Object) my_object.Object {
+		switch obj := obj.(type) {
+		case *my_object.Boolean:
+			return obj
+		case *my_object.Null:
+			return FALSE
+		case *my_object.Integer:
+			return nativeBoolToBooleanObject(obj.Value != 0)
+		case *my_object.BigInteger:
+			return TRUE
+		case *my_object.Float:
+			return nativeBoolToBooleanObject(obj.Value != 0)
+		case *my_object.String:
+			switch obj.Value {
+			case "true":
+				return TRUE
+			case "false":
+				return FALSE
+			}
+		}
+		return conversionError(obj, my_object.BOOLEAN_OBJ)
+	}),
+	"type": conversionFunc("type", func(obj my_object.Object) my_object.Object {
+		return newString(string(obj.Type()))
+	}),
+
+	"isInt":      predicateOf("isInt", my_object.INTEGER_OBJ),
+	"isFloat":    predicateOf("isFloat", my_object.FLOAT_OBJ),
+	"isNumber":   predicateOf("isNumber", my_object.INTEGER_OBJ, my_object.FLOAT_OBJ),
+	"isString":   predicateOf("isString", my_object.STRING_OBJ),
+	"isBool":     predicateOf("isBool", my_object.BOOLEAN_OBJ),
+	"isNull":     predicateOf("isNull", my_object.NULL_OBJ),
+	"isArray":    predicateOf("isArray", my_object.ARRAY_OBJ),
+	"isTuple":    predicateOf("isTuple", my_object.TUPLE_OBJ),
+	"isHash":     predicateOf("isHash", my_object.HASH_OBJ),
+	"isFunction": predicateOf("isFunction", my_object.FUNCTION_OBJ, my_object.BUILTIN_OBJ),
+}
@@ -0,0 +1,53 @@
+package my_evaluator
+
+import (
+	"errors"
+	"fmt"
+	"monkey/my_object"
+)
+
+// Call invokes a monkey FUNCTION or BUILTIN from go code;
+// args are converted with ToObject and the result with FromObject;
+// an ERROR object produced by the call is returned as a go error;
+// it is safe to call from multiple goroutines at the same time as long as
+// the arrays and hashes they share are not changed, e.g. with delete,
+// since those are not synchronized
+func Call(fn my_object.Object, args ...interface{}) (interface{}, error) {
+	result, err := CallObject(fn, args...)
+	if err != nil {
+		return nil, err
+	}
+	return FromObject(result)
+}
+
+// CallObject is like Call but leaves the result as a monkey object
+func CallObject(fn my_object.Object, args ...interface{}) (result my_object.Object, err error) {
+	switch fn.(type) {
+	case *my_object.Function, *my_object.Builtin:
+	case nil:
+		return nil, errors.New("not a function: nil")
+	default:
+		return nil, fmt.Errorf("not a function: %s", fn.Type())
+	}
+	argObjs := make([]my_object.Object, 0, len(args))
+	for idx, arg := range args {
+		argObj, err := ToObject(arg)
+		if err != nil {
+			return nil, fmt.Errorf("argument %d: %w", idx+1, err)
+		}
+		argObjs = append(argObjs, argObj)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			result, err = nil, fmt.Errorf("panic during call: %v", r)
+		}
+	}()
+	result = applyFunction(fn, argObjs)
+	if errObj, eok := result.(*my_object.Error); eok {
+		return nil, errors.New(errObj.Message)
+	}
+	if result == nil {
+		result = NULL
+	}
+	return result, nil
+}
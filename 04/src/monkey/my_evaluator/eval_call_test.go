@@ -0,0 +1,89 @@
+package my_evaluator
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCallFunction(t *testing.T) {
+	fn := testEval(t, `let base = 10; fn(x, y) { if (x > y) { return base + x; }; base + y }`)
+
+	result, err := Call(fn, 5, 2)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 15, result)
+
+	result, err = Call(fn, 1, 2.5)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 12.5, result)
+
+	_, err = Call(fn, 1)
+	assert.EqualError(t, err, "wrong number of arguments: got=1, want=2")
+
+	_, err = Call(fn, "a", 1)
+	assert.EqualError(t, err, "unknown operator: STRING>INT")
+
+	_, err = Call(testEval(t, "1"))
+	assert.EqualError(t, err, "not a function: INT")
+}
+
+func TestCallBuiltinAndCallback(t *testing.T) {
+	result, err := Call(testEval(t, "len"), []string{"a", "b"})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, result)
+
+	apply := testEval(t, "fn(f, x) { f(x) }")
+	result, err = Call(apply, func(s string) string { return s + "!" }, "hi")
+	assert.NoError(t, err)
+	assert.Equal(t, "hi!", result)
+
+	predicate := testEval(t, "fn(x) { x > 3 }")
+	result, err = Call(apply, predicate, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, true, result)
+}
+
+func TestCallConcurrently(t *testing.T) {
+	fn := testEval(t, "let double = fn(x) { x * 2 }; fn(x) { let y = double(x); y + 1 }")
+	wg := sync.WaitGroup{}
+	for i := 0; i < 32; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			result, err := Call(fn, i)
+			assert.NoError(t, err)
+			assert.EqualValues(t, i*2+1, result)
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestCallConcurrentlyRebinding(t *testing.T) {
+	// NOTE: rebinding a shared function must not write to it, see go test -race
+	apply := testEval(t, "fn(f, x) { let g = f; g(x) }")
+	inc := testEval(t, "fn(x) { x + 1 }")
+	wg := sync.WaitGroup{}
+	for i := 0; i < 32; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			result, err := Call(apply, inc, i)
+			assert.NoError(t, err)
+			assert.EqualValues(t, i+1, result)
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, "<fn anonymous/1>", inc.Inspect())
+}
+
+func TestSortByCallsKeyOncePerElement(t *testing.T) {
+	seen := []int{}
+	result, err := Call(testEval(t, "sortBy"), []int{3, 1, 4, 1, 5, 9, 2, 6}, func(x int) int {
+		seen = append(seen, x)
+		return -x
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{int64(9), int64(6), int64(5), int64(4), int64(3), int64(2), int64(1), int64(1)}, result)
+	assert.Equal(t, []int{3, 1, 4, 1, 5, 9, 2, 6}, seen)
+}
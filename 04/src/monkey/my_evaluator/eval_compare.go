@@ -0,0 +1,199 @@
+package my_evaluator
+
+import (
+	"fmt"
+	"monkey/my_ast"
+	"monkey/my_object"
+	"strings"
+)
+
+// isStructural: strings, arrays, tuples and hashes are compared by value
+// rather than by the numeric conversions other objects go through
+func isStructural(obj my_object.Object) bool {
+	switch obj.(type) {
+	case *my_object.String, *my_object.Array, *my_object.Tuple, *my_object.Hash:
+		return true
+	}
+	return false
+}
+
+func evalStructuralInfixExpression(
+	operator my_ast.InfixOperator, left, right my_object.Object,
+) my_object.Object {
+	switch operator {
+	case my_ast.INOP_EQ:
+		return nativeBoolToBooleanObject(objectsEqual(left, right))
+	case my_ast.INOP_NOT_EQ:
+		return nativeBoolToBooleanObject(!objectsEqual(left, right))
+	case my_ast.INOP_PLUS:
+		leftStr, lok := left.(*my_object.String)
+		rightStr, rok := right.(*my_object.String)
+		if lok && rok {
+			return &my_object.String{Value: leftStr.Value + rightStr.Value}
+		}
+	case my_ast.INOP_LT, my_ast.INOP_GT:
+		if !isOrderable(left) || left.Type() != right.Type() {
+			break
+		}
+		cmp, err := compareObjects(left, right)
+		if err != nil {
+			return newError("%s", err)
+		}
+		if operator == my_ast.INOP_LT {
+			return nativeBoolToBooleanObject(cmp < 0)
+		}
+		return nativeBoolToBooleanObject(cmp > 0)
+	}
+	return newError("unknown operator: %s%s%s", left.Type(), operator, right.Type())
+}
+
+// objectsEqual: deep equality used by == and !=;
+// numbers and booleans compare after conversion like `1 == 1.0`,
+// hashes compare regardless of insertion order,
+// values of other types are equal only if they are the same object
+func objectsEqual(left, right my_object.Object) bool {
+	c := &comparer{seen: map[objectPair]bool{}}
+	return c.equal(left, right)
+}
+
+// compareObjects: lexicographic ordering of numbers, strings, arrays and tuples;
+// returns <0, 0 or >0 and an error for values that have no ordering
+func compareObjects(left, right my_object.Object) (int, error) {
+	c := &comparer{seen: map[objectPair]bool{}}
+	return c.compare(left, right)
+}
+
+type objectPair struct {
+	left, right my_object.Object
+}
+
+// comparer: remembers the container pairs being compared
+// so self-referencing values do not recurse forever
+type comparer struct {
+	seen map[objectPair]bool
+}
+
+// enter: reports whether the pair is already being compared further up
+func (c *comparer) enter(left, right my_object.Object) bool {
+	pair := objectPair{left, right}
+	if c.seen[pair] {
+		return true
+	}
+	c.seen[pair] = true
+	return false
+}
+
+func (c *comparer) equal(left, right my_object.Object) bool {
+	// NOTE: numbers first, so that nan is unequal to itself inside containers too
+	if isNumeric(left) {
+		return isNumeric(right) && evalInfixExpression(my_ast.INOP_EQ, left, right) == TRUE
+	}
+	if left == right {
+		return true
+	}
+	switch left := left.(type) {
+	case *my_object.String:
+		right, ok := right.(*my_object.String)
+		return ok && left.Value == right.Value
+	case *my_object.Null:
+		_, ok := right.(*my_object.Null)
+		return ok
+	case *my_object.Array:
+		right, ok := right.(*my_object.Array)
+		return ok && (c.enter(left, right) || c.elementsEqual(left.Elements, right.Elements))
+	case *my_object.Tuple:
+		right, ok := right.(*my_object.Tuple)
+		return ok && (c.enter(left, right) || c.elementsEqual(left.Elements, right.Elements))
+	case *my_object.Hash:
+		right, ok := right.(*my_object.Hash)
+		if !ok || left.Len() != right.Len() {
+			return false
+		}
+		if c.enter(left, right) {
+			return true
+		}
+		for _, pair := range left.Pairs() {
+			key, _ := my_object.AsHashable(pair.Key)
+			other, ok := right.Get(key)
+			if !ok || !c.equal(pair.Value, other.Value) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func (c *comparer) elementsEqual(left, right []my_object.Object) bool {
+	if len(left) != len(right) {
+		return false
+	}
+	for i := range left {
+		if !c.equal(left[i], right[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *comparer) compare(left, right my_object.Object) (int, error) {
+	switch {
+	case isNumeric(left) && isNumeric(right):
+		return compareNumbers(left, right), nil
+	case left.Type() != right.Type() || !isOrderable(left):
+		return 0, fmt.Errorf("cannot compare %s with %s", left.Type(), right.Type())
+	}
+	switch left := left.(type) {
+	case *my_object.String:
+		return strings.Compare(left.Value, right.(*my_object.String).Value), nil
+	case *my_object.Array:
+		if c.enter(left, right) {
+			return 0, nil
+		}
+		return c.compareElements(left.Elements, right.(*my_object.Array).Elements)
+	case *my_object.Tuple:
+		if c.enter(left, right) {
+			return 0, nil
+		}
+		return c.compareElements(left.Elements, right.(*my_object.Tuple).Elements)
+	}
+	return 0, nil
+}
+
+func (c *comparer) compareElements(left, right []my_object.Object) (int, error) {
+	for i := 0; i < len(left) && i < len(right); i++ {
+		cmp, err := c.compare(left[i], right[i])
+		if err != nil || cmp != 0 {
+			return cmp, err
+		}
+	}
+	return len(left) - len(right), nil
+}
+
+func isNumeric(obj my_object.Object) bool {
+	switch obj.(type) {
+	case *my_object.Integer, *my_object.BigInteger, *my_object.Float, *my_object.Boolean:
+		return true
+	}
+	return false
+}
+
+func isOrderable(obj my_object.Object) bool {
+	switch obj.(type) {
+	case *my_object.Integer, *my_object.BigInteger, *my_object.Float, *my_object.Boolean,
+		*my_object.String, *my_object.Array, *my_object.Tuple:
+		return true
+	}
+	return false
+}
+
+// compareNumbers: integers compare exactly, anything involving a float as floats
+func compareNumbers(left, right my_object.Object) int {
+	if evalInfixExpression(my_ast.INOP_LT, left, right) == TRUE {
+		return -1
+	}
+	if evalInfixExpression(my_ast.INOP_GT, left, right) == TRUE {
+		return 1
+	}
+	return 0
+}
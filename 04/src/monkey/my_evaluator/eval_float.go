@@ -0,0 +1,60 @@
+package my_evaluator
+
+import (
+	"math"
+	"monkey/my_ast"
+	"monkey/my_object"
+	"sync/atomic"
+)
+
+// FloatMode: how float arithmetic treats division by zero and results
+// that are not finite; integer division by zero is always an error
+type FloatMode int32
+
+const (
+	// FloatIEEE: IEEE 754 semantics, e.g. 1.0/0 is inf, -1.0/0 is -inf and 0.0/0 is nan;
+	// nan is unequal to everything including itself and every ordering with it is false
+	FloatIEEE FloatMode = iota
+	// FloatStrict: float division by zero is an error like integer division,
+	// so is any arithmetic turning finite operands into inf or nan
+	FloatStrict
+)
+
+var floatMode int32 = int32(FloatIEEE)
+
+// SetFloatMode: sets the FloatMode used by all later evaluations
+func SetFloatMode(mode FloatMode) {
+	atomic.StoreInt32(&floatMode, int32(mode))
+}
+
+// GetFloatMode: the FloatMode in use, FloatIEEE by default
+func GetFloatMode() FloatMode {
+	return FloatMode(atomic.LoadInt32(&floatMode))
+}
+
+// evalFloatArithmetic: +, -, *, / and % on floats following the FloatMode
+func evalFloatArithmetic(operator my_ast.InfixOperator, left, right float64) my_object.Object {
+	strict := GetFloatMode() == FloatStrict
+	var result float64
+	switch operator {
+	case "+":
+		result = left + right
+	case "-":
+		result = left - right
+	case "*":
+		result = left * right
+	case "/", "%":
+		if strict && right == 0 {
+			return newError("division by zero")
+		}
+		if operator == "/" {
+			result = left / right
+		} else {
+			result = math.Mod(left, right)
+		}
+	}
+	if strict && !my_object.IsFiniteFloat(result) && my_object.IsFiniteFloat(left) && my_object.IsFiniteFloat(right) {
+		return newError("float overflow: %g%s%g", left, operator, right)
+	}
+	return &my_object.Float{Value: result}
+}
@@ -17,6 +17,22 @@ func evalExpressions(exps []my_ast.Expression, env *my_object.Environment) []my_
 	return args
 }
 
+// applyFunction calls a FUNCTION or BUILTIN object with evaluated arguments
+func applyFunction(fn my_object.Object, args []my_object.Object) my_object.Object {
+	switch fn := fn.(type) {
+	case *my_object.Builtin:
+		return fn.Fn(args...)
+	case *my_object.Function:
+		if len(args) != len(fn.Parameters) {
+			return newError("wrong number of arguments: got=%d, want=%d", len(args), len(fn.Parameters))
+		}
+		// extend env var now to create new set of bindings
+		return evalFunction(fn, args)
+	default:
+		return newError("not a function: %s", fn.Type())
+	}
+}
+
 func evalFunction(fn *my_object.Function, args []my_object.Object) my_object.Object {
 	env := my_object.NewEnclosedEnvironment(fn.Env)
 	for idx, param := range fn.Parameters {
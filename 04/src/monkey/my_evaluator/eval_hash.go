@@ -6,18 +6,26 @@ import (
 )
 
 func evalHashExpression(node *my_ast.HashExpression, env *my_object.Environment) my_object.Object {
-	pairs := make(map[my_object.HashKey]my_object.HashPair)
-	for kn, vn := range node.Pairs {
+	hash := my_object.NewHash()
+	// NOTE: in the order of source codes
+	for _, kn := range node.Keys {
+		vn := node.Pairs[kn]
 		if ksn, kok := kn.(*my_ast.Identifier); kok {
 			kn = &my_ast.StringExpression{Value: ksn.Value}
 		}
 		key := Eval(kn, env)
-		hashableKey, hok := key.(my_object.HashableObject)
-		if !hok {
-			return newError("key type not hashable: %s", key.Type())
+		if isError(key) {
+			return key
+		}
+		hashableKey, err := hashKey(key)
+		if err != nil {
+			return err
 		}
 		value := Eval(vn, env)
-		pairs[hashableKey.HashKey()] = my_object.HashPair{Key: key, Value: value}
+		if isError(value) {
+			return value
+		}
+		hash.Set(hashableKey, value)
 	}
-	return &my_object.Hash{Pairs: pairs}
+	return hash
 }
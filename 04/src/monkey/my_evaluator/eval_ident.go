@@ -10,8 +10,11 @@ func evalIdentifier(node *my_ast.Identifier, env *my_object.Environment) my_obje
 	if ok {
 		return val
 	}
-	if fn, ok := builtins[node.Value]; ok {
+	if fn, ok := lookupBuiltin(node.Value); ok {
 		return fn
 	}
+	if module, ok := lookupModule(node.Value); ok {
+		return module
+	}
 	return newError("identifier not found: %s", node.Value)
 }
@@ -9,11 +9,7 @@ import (
 func evalIndexExpression(left my_object.Object, indexNode *my_ast.IndexExpression, env *my_object.Environment) my_object.Object {
 	switch left := left.(type) {
 	case *my_object.String:
-		elements := []my_object.Object{}
-		for _, char := range left.Value {
-			elements = append(elements, &my_object.String{Value: string(char)})
-		}
-		stringArr := &my_object.Array{Elements: elements}
+		stringArr := stringsToArray(splitChars(left.Value))
 		returnedStringArr := evalArrayIndexExpression(stringArr, indexNode, env)
 		if isError(returnedStringArr) {
 			return returnedStringArr
@@ -28,7 +24,18 @@ func evalIndexExpression(left my_object.Object, indexNode *my_ast.IndexExpressio
 		return &my_object.String{Value: sb.String()}
 
 	case *my_object.Array:
-		return evalArrayIndexExpression(left, indexNode, env)
+		returned := evalArrayIndexExpression(left, indexNode, env)
+		// NOTE: slices of a frozen array are frozen as well
+		if arr, aok := returned.(*my_object.Array); aok && left.Frozen && isSliceIndex(indexNode) {
+			return &my_object.Array{Elements: arr.Elements, Frozen: true}
+		}
+		return returned
+	case *my_object.Tuple:
+		returned := evalArrayIndexExpression(&my_object.Array{Elements: left.Elements}, indexNode, env)
+		if arr, aok := returned.(*my_object.Array); aok && isSliceIndex(indexNode) {
+			return &my_object.Tuple{Elements: arr.Elements}
+		}
+		return returned
 	case *my_object.Hash:
 		return evalHashIndexExpression(left, indexNode.StartIndex, env)
 	default:
@@ -36,6 +43,22 @@ func evalIndexExpression(left my_object.Object, indexNode *my_ast.IndexExpressio
 	}
 }
 
+func isSliceIndex(indexNode *my_ast.IndexExpression) bool {
+	return indexNode.IsSetEndIndex || indexNode.IsSetStride
+}
+
+// clampedIndex: the INT obj as an end index or a stride, where big integers
+// stand for bound of their sign, which slices the same way; false if not an INT
+func clampedIndex(obj my_object.Object, bound int64) (int64, bool) {
+	switch obj := obj.(type) {
+	case *my_object.Integer:
+		return obj.Value, true
+	case *my_object.BigInteger:
+		return int64(obj.Value.Sign()) * bound, true
+	}
+	return 0, false
+}
+
 func evalArrayIndexExpression(array *my_object.Array, indexNode *my_ast.IndexExpression, env *my_object.Environment) my_object.Object {
 	// shortcut: if no start or end index or stride, return error
 	if !indexNode.IsSetStartIndex {
@@ -45,6 +68,10 @@ func evalArrayIndexExpression(array *my_object.Array, indexNode *my_ast.IndexExp
 	startIdx := int64(0)
 	if indexNode.StartIndex != nil {
 		startIdxEvalObj := Eval(indexNode.StartIndex, env)
+		// NOTE: big integers are out of any array
+		if big, bok := startIdxEvalObj.(*my_object.BigInteger); bok {
+			return newError("index %s out of array with length %d", big.Value, len(array.Elements))
+		}
 		startIdxEvalObjInt, iok := startIdxEvalObj.(*my_object.Integer)
 		if !iok {
 			return newError("array-like indexing expecting INT, but got %s", startIdxEvalObj.Type())
@@ -68,11 +95,10 @@ func evalArrayIndexExpression(array *my_object.Array, indexNode *my_ast.IndexExp
 	endIdx := int64(len(array.Elements))
 	if indexNode.EndIndex != nil {
 		endIdxEvalObj := Eval(indexNode.EndIndex, env)
-		endIdxEvalObjInt, eok := endIdxEvalObj.(*my_object.Integer)
-		if !eok {
+		var eok bool
+		if endIdx, eok = clampedIndex(endIdxEvalObj, int64(len(array.Elements))); !eok {
 			return newError("array-like indexing expecting INT, but got %s", endIdxEvalObj.Type())
 		}
-		endIdx = endIdxEvalObjInt.Value
 	}
 	if endIdx >= int64(len(array.Elements)) {
 		endIdx = int64(len(array.Elements))
@@ -88,11 +114,10 @@ func evalArrayIndexExpression(array *my_object.Array, indexNode *my_ast.IndexExp
 	stride := int64(1)
 	if indexNode.Stride != nil {
 		strideEvalObj := Eval(indexNode.Stride, env)
-		strideEvalObjInt, sok := strideEvalObj.(*my_object.Integer)
-		if !sok {
+		var sok bool
+		if stride, sok = clampedIndex(strideEvalObj, int64(len(array.Elements))+1); !sok {
 			return newError("array-like indexing expecting INT, but got %s", strideEvalObj.Type())
 		}
-		stride = strideEvalObjInt.Value
 	}
 	if stride == 0 {
 		return newError("array-like indexing expecting non-zero stride")
@@ -126,12 +151,29 @@ func evalHashIndexExpression(hash *my_object.Hash, index my_ast.Expression, env
 	if isError(indexObj) {
 		return indexObj
 	}
-	key, ok := indexObj.(my_object.HashableObject)
+	key, err := hashKey(indexObj)
+	if err != nil {
+		return err
+	}
+	pair, ok := hash.Get(key)
+	if !ok {
+		return NULL
+	}
+	return pair.Value
+}
+
+// evalMemberExpression: h.name is h["name"] for hashes;
+// missing members are null, except for modules where they are errors
+func evalMemberExpression(left my_object.Object, property *my_ast.Identifier) my_object.Object {
+	hash, ok := left.(*my_object.Hash)
 	if !ok {
-		return newError("key type not hashable: %s", indexObj.Type())
+		return newError("member access not supported: %s", left.Type())
 	}
-	pair, ok := hash.Pairs[key.HashKey()]
+	pair, ok := hash.Get(&my_object.String{Value: property.Value})
 	if !ok {
+		if name, isModule := moduleName(hash); isModule {
+			return newError("unknown member: %s.%s", name, property.Value)
+		}
 		return NULL
 	}
 	return pair.Value
@@ -1,6 +1,8 @@
 package my_evaluator
 
 import (
+	"math"
+	"math/big"
 	"monkey/my_ast"
 	"monkey/my_object"
 )
@@ -10,77 +12,86 @@ func evalInfixNode(node *my_ast.InfixExpression, env *my_object.Environment) my_
 	if isError(leftObj) {
 		return leftObj
 	}
+	// NOTE: ?? only evaluates its right operand when the left one is null
+	if node.Operator == my_ast.INOP_NULLISH {
+		if isNull(leftObj) {
+			return Eval(node.Right, env)
+		}
+		return leftObj
+	}
 	rightObj := Eval(node.Right, env)
 	if isError(rightObj) {
 		return rightObj
 	}
+	return evalInfixExpression(node.Operator, leftObj, rightObj)
+}
+
+// evalInfixExpression: applies operator to evaluated operands;
+// strings, arrays, tuples and hashes compare by structure, see eval_compare.go
+func evalInfixExpression(
+	operator my_ast.InfixOperator, leftObj, rightObj my_object.Object,
+) my_object.Object {
+	if isNull(leftObj) || isNull(rightObj) {
+		return evalNullInfixExpression(operator, leftObj, rightObj)
+	}
+	if isStructural(leftObj) || isStructural(rightObj) {
+		return evalStructuralInfixExpression(operator, leftObj, rightObj)
+	}
 	switch leftObj := leftObj.(type) {
 	case *my_object.Integer:
 		switch rightObj := rightObj.(type) {
 		case *my_object.Integer:
-			return evalIntegerInfixExpression(node.Operator, leftObj, rightObj)
+			return evalIntegerInfixExpression(operator, leftObj, rightObj)
 		case *my_object.Boolean:
-			return evalIntegerInfixExpression(node.Operator, leftObj, booleanToIntObject(rightObj))
+			return evalIntegerInfixExpression(operator, leftObj, booleanToIntObject(rightObj))
 		case *my_object.Float:
-			return evalFloatInfixExpression(node.Operator, integerToFloatObject(leftObj), rightObj)
-		case *my_object.Null:
-			return newError("unknown operator: %s%s%s", leftObj.Type(), node.Operator, rightObj.Type())
+			return evalFloatInfixExpression(operator, integerToFloatObject(leftObj), rightObj)
+		case *my_object.BigInteger:
+			return evalBigIntegerInfixExpression(operator, big.NewInt(leftObj.Value), rightObj.Value)
 		default:
-			return newError("unknown operator: %s%s%s", leftObj.Type(), node.Operator, rightObj.Type())
+			return newError("unknown operator: %s%s%s", leftObj.Type(), operator, rightObj.Type())
 		}
 	case *my_object.Boolean:
 		switch rightObj := rightObj.(type) {
 		case *my_object.Integer:
-			return evalIntegerInfixExpression(node.Operator, booleanToIntObject(leftObj), rightObj)
+			return evalIntegerInfixExpression(operator, booleanToIntObject(leftObj), rightObj)
 		case *my_object.Boolean:
-			return evalIntegerInfixExpression(node.Operator, booleanToIntObject(leftObj), booleanToIntObject(rightObj))
+			return evalIntegerInfixExpression(operator, booleanToIntObject(leftObj), booleanToIntObject(rightObj))
 		case *my_object.Float:
-			return evalFloatInfixExpression(node.Operator, booleanToFloatObject(leftObj), rightObj)
-		case *my_object.Null:
-			return newError("unknown operator: %s%s%s", leftObj.Type(), node.Operator, rightObj.Type())
+			return evalFloatInfixExpression(operator, booleanToFloatObject(leftObj), rightObj)
+		case *my_object.BigInteger:
+			return evalBigIntegerInfixExpression(operator, big.NewInt(booleanToIntObject(leftObj).Value), rightObj.Value)
 		default:
-			return newError("unknown operator: %s%s%s", leftObj.Type(), node.Operator, rightObj.Type())
+			return newError("unknown operator: %s%s%s", leftObj.Type(), operator, rightObj.Type())
 		}
 	case *my_object.Float:
 		switch rightObj := rightObj.(type) {
 		case *my_object.Integer:
-			return evalFloatInfixExpression(node.Operator, leftObj, integerToFloatObject(rightObj))
+			return evalFloatInfixExpression(operator, leftObj, integerToFloatObject(rightObj))
 		case *my_object.Boolean:
-			return evalFloatInfixExpression(node.Operator, leftObj, booleanToFloatObject(rightObj))
+			return evalFloatInfixExpression(operator, leftObj, booleanToFloatObject(rightObj))
 		case *my_object.Float:
-			return evalFloatInfixExpression(node.Operator, leftObj, rightObj)
-		case *my_object.Null:
-			return newError("unknown operator: %s%s%s", leftObj.Type(), node.Operator, rightObj.Type())
+			return evalFloatInfixExpression(operator, leftObj, rightObj)
+		case *my_object.BigInteger:
+			return evalFloatInfixExpression(operator, leftObj, bigIntegerToFloatObject(rightObj))
 		default:
-			return newError("unknown operator: %s%s%s", leftObj.Type(), node.Operator, rightObj.Type())
+			return newError("unknown operator: %s%s%s", leftObj.Type(), operator, rightObj.Type())
 		}
-	case *my_object.Null:
-		// TODO: NULL==NULL? NULL>=1 yields false or NULL?
-		if _, ok := rightObj.(*my_object.Null); ok {
-			switch node.Operator {
-			case "<":
-				fallthrough
-			case "!=":
-				fallthrough
-			case ">":
-				return FALSE
-			case "==":
-				return TRUE
-			default:
-				return newError("unknown operator: %s%s%s", leftObj.Type(), node.Operator, rightObj.Type())
-			}
-		}
-		// an error?
-		return newError("unknown operator: %s%s%s", leftObj.Type(), node.Operator, rightObj.Type())
-	case *my_object.String:
-		if rightObj, ok := rightObj.(*my_object.String); ok {
-			if node.Operator == "+" {
-				return &my_object.String{Value: leftObj.Value + rightObj.Value}
-			}
+	case *my_object.BigInteger:
+		switch rightObj := rightObj.(type) {
+		case *my_object.Integer:
+			return evalBigIntegerInfixExpression(operator, leftObj.Value, big.NewInt(rightObj.Value))
+		case *my_object.Boolean:
+			return evalBigIntegerInfixExpression(operator, leftObj.Value, big.NewInt(booleanToIntObject(rightObj).Value))
+		case *my_object.Float:
+			return evalFloatInfixExpression(operator, bigIntegerToFloatObject(leftObj), rightObj)
+		case *my_object.BigInteger:
+			return evalBigIntegerInfixExpression(operator, leftObj.Value, rightObj.Value)
+		default:
+			return newError("unknown operator: %s%s%s", leftObj.Type(), operator, rightObj.Type())
 		}
-		return newError("unknown operator: %s%s%s", leftObj.Type(), node.Operator, rightObj.Type())
 	default:
-		return newError("unknown operator: %s%s%s", leftObj.Type(), node.Operator, rightObj.Type())
+		return newError("unknown operator: %s%s%s", leftObj.Type(), operator, rightObj.Type())
 	}
 }
 
@@ -89,15 +100,32 @@ func evalIntegerInfixExpression(
 ) my_object.Object {
 	leftVal := left.Value
 	rightVal := right.Value
+	// NOTE: results overflowing int64 are promoted to BigInteger
 	switch operator {
 	case "+":
-		return &my_object.Integer{Value: leftVal + rightVal}
+		if sum, ok := addInt64(leftVal, rightVal); ok {
+			return &my_object.Integer{Value: sum}
+		}
 	case "-":
-		return &my_object.Integer{Value: leftVal - rightVal}
+		if diff, ok := subInt64(leftVal, rightVal); ok {
+			return &my_object.Integer{Value: diff}
+		}
 	case "*":
-		return &my_object.Integer{Value: leftVal * rightVal}
+		if prod, ok := mulInt64(leftVal, rightVal); ok {
+			return &my_object.Integer{Value: prod}
+		}
 	case "/":
-		return &my_object.Integer{Value: leftVal / rightVal}
+		if rightVal == 0 {
+			return newError("division by zero")
+		}
+		if leftVal != math.MinInt64 || rightVal != -1 {
+			return &my_object.Integer{Value: leftVal / rightVal}
+		}
+	case "%":
+		if rightVal == 0 {
+			return newError("division by zero")
+		}
+		return &my_object.Integer{Value: leftVal % rightVal}
 	case "<":
 		return nativeBoolToBooleanObject(leftVal < rightVal)
 	case ">":
@@ -109,6 +137,7 @@ func evalIntegerInfixExpression(
 	default:
 		return newError("unknown operator: %s%s%s", left.Type(), operator, right.Type())
 	}
+	return evalBigIntegerInfixExpression(operator, big.NewInt(leftVal), big.NewInt(rightVal))
 }
 
 func evalFloatInfixExpression(
@@ -117,14 +146,8 @@ func evalFloatInfixExpression(
 	leftVal := left.Value
 	rightVal := right.Value
 	switch operator {
-	case "+":
-		return &my_object.Float{Value: leftVal + rightVal}
-	case "-":
-		return &my_object.Float{Value: leftVal - rightVal}
-	case "*":
-		return &my_object.Float{Value: leftVal * rightVal}
-	case "/":
-		return &my_object.Float{Value: leftVal / rightVal}
+	case "+", "-", "*", "/", "%":
+		return evalFloatArithmetic(operator, leftVal, rightVal)
 	case "<":
 		return nativeBoolToBooleanObject(leftVal < rightVal)
 	case ">":
@@ -137,3 +160,17 @@ func evalFloatInfixExpression(
 		return newError("unknown operator: %s%s%s", left.Type(), operator, right.Type())
 	}
 }
+
+// evalNullInfixExpression: null only equals null;
+// ordering and arithmetic with null are errors rather than silently false
+func evalNullInfixExpression(
+	operator my_ast.InfixOperator, left, right my_object.Object,
+) my_object.Object {
+	switch operator {
+	case my_ast.INOP_EQ:
+		return nativeBoolToBooleanObject(isNull(left) && isNull(right))
+	case my_ast.INOP_NOT_EQ:
+		return nativeBoolToBooleanObject(!(isNull(left) && isNull(right)))
+	}
+	return newError("unknown operator: %s%s%s", left.Type(), operator, right.Type())
+}
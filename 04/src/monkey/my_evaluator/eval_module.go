@@ -0,0 +1,64 @@
+package my_evaluator
+
+import (
+	"monkey/my_object"
+	"sort"
+)
+
+// modules: frozen hashes of builtins and constants reached with dot syntax,
+// e.g. math.sqrt(2); like builtins, names bound in the environment shadow them
+var modules = map[string]*my_object.Hash{}
+
+// newModule: a frozen hash of members in the order of their names;
+// builtins are named after the module, e.g. <builtin math.sqrt>
+func newModule(name string, members map[string]my_object.Object) *my_object.Hash {
+	names := make([]string, 0, len(members))
+	for member := range members {
+		names = append(names, member)
+	}
+	sort.Strings(names)
+	module := my_object.NewHash()
+	for _, member := range names {
+		value := members[member]
+		if builtin, ok := value.(*my_object.Builtin); ok {
+			builtin.Name = name + "." + member
+		}
+		module.Set(&my_object.String{Value: member}, value)
+	}
+	module.Frozen = true
+	return module
+}
+
+func lookupModule(name string) (*my_object.Hash, bool) {
+	builtinsMu.RLock()
+	defer builtinsMu.RUnlock()
+	module, ok := modules[name]
+	return module, ok
+}
+
+// moduleName: name of the module hash is, false if it is not a module
+func moduleName(hash *my_object.Hash) (string, bool) {
+	if !hash.Frozen {
+		return "", false
+	}
+	builtinsMu.RLock()
+	defer builtinsMu.RUnlock()
+	for name, module := range modules {
+		if module == hash {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// ModuleNames: sorted names of all modules
+func ModuleNames() []string {
+	builtinsMu.RLock()
+	defer builtinsMu.RUnlock()
+	names := make([]string, 0, len(modules))
+	for name := range modules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
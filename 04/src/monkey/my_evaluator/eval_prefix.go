@@ -1,6 +1,8 @@
 package my_evaluator
 
 import (
+	"math"
+	"math/big"
 	"monkey/my_ast"
 	"monkey/my_object"
 )
@@ -37,7 +39,12 @@ func evalPrefixOperatorMinus(right my_object.Object) my_object.Object {
 	case *my_object.Float:
 		return &my_object.Float{Value: -right.Value}
 	case *my_object.Integer:
+		if right.Value == math.MinInt64 {
+			return bigToIntegerObject(new(big.Int).Neg(big.NewInt(right.Value)))
+		}
 		return &my_object.Integer{Value: -right.Value}
+	case *my_object.BigInteger:
+		return bigToIntegerObject(new(big.Int).Neg(right.Value))
 	// case *my_object.UnsignedInteger:
 	// 	if right.Value > math.MaxInt64 {
 	// 		// NOTE: Cannot convert correctly!
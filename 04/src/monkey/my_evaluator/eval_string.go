@@ -0,0 +1,23 @@
+package my_evaluator
+
+import (
+	"monkey/my_ast"
+	"monkey/my_object"
+	"strings"
+)
+
+// evalInterpolatedString: joins the texts with the display forms
+// of the embedded expressions, evaluated from left to right
+func evalInterpolatedString(node *my_ast.InterpolatedString, env *my_object.Environment) my_object.Object {
+	sb := &strings.Builder{}
+	for idx, expr := range node.Exprs {
+		sb.WriteString(node.Texts[idx])
+		obj := Eval(expr, env)
+		if isError(obj) {
+			return obj
+		}
+		sb.WriteString(obj.String())
+	}
+	sb.WriteString(node.Texts[len(node.Exprs)])
+	return &my_object.String{Value: sb.String()}
+}
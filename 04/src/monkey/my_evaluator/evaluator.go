@@ -1,6 +1,7 @@
 package my_evaluator
 
 import (
+	"math/big"
 	"monkey/my_ast"
 	"monkey/my_object"
 )
@@ -22,6 +23,11 @@ func Eval(node my_ast.Node, env *my_object.Environment) my_object.Object {
 		if isError(val) {
 			return val
 		}
+		// NOTE: only a function made right here is named, as a bound one
+		// may be shared, e.g. let g = f, and must not change under other callers
+		if _, isLiteral := node.Value.(*my_ast.Function); isLiteral {
+			val.(*my_object.Function).Name = node.Ident.Value
+		}
 		env.Set(node.Ident.Value, val)
 		return nil
 	case *my_ast.CallExpression:
@@ -30,18 +36,10 @@ func Eval(node my_ast.Node, env *my_object.Environment) my_object.Object {
 			return function
 		}
 		args := evalExpressions(node.Arguments, env)
-		switch function := function.(type) {
-		case *my_object.Builtin:
-			return function.Fn(args...)
-		case *my_object.Function:
-			if len(args) == 1 && isError(args[0]) {
-				return args[0]
-			}
-			// extend env var now to create new set of bindings
-			return evalFunction(function, args)
-		default:
-			return newError("not a function: %s", function.Type())
+		if len(args) == 1 && isError(args[0]) {
+			return args[0]
 		}
+		return applyFunction(function, args)
 	case *my_ast.Function:
 		return &my_object.Function{Parameters: node.Parameters, Env: env, Body: node.Body}
 	case *my_ast.IfExpression:
@@ -55,26 +53,48 @@ func Eval(node my_ast.Node, env *my_object.Environment) my_object.Object {
 	case *my_ast.Boolean:
 		return booleanNodeToObject(node)
 	case *my_ast.Integer:
-		// NOTE: Risk of overflow here
-		// but, introducing an unsigned int will quickly get messy
-		// when applying infix calculation involving overflow
-		return &my_object.Integer{Value: int64(node.Value)}
+		// NOTE: literals beyond int64 become BigInteger
+		return uintToIntegerObject(node.Value)
+	case *my_ast.BigInteger:
+		return bigToIntegerObject(new(big.Int).Set(node.Value))
 	case *my_ast.Float:
 		return &my_object.Float{Value: node.Value}
+	case *my_ast.Null:
+		return NULL
 	case *my_ast.StringExpression:
 		return &my_object.String{Value: node.Value}
+	case *my_ast.InterpolatedString:
+		return evalInterpolatedString(node, env)
 	case *my_ast.ArrayExpression:
 		elements := evalExpressions(node.Elements, env)
 		if len(elements) == 1 && isError(elements[0]) {
 			return elements[0]
 		}
 		return &my_object.Array{Elements: elements}
+	case *my_ast.TupleExpression:
+		elements := evalExpressions(node.Elements, env)
+		if len(elements) == 1 && isError(elements[0]) {
+			return elements[0]
+		}
+		return &my_object.Tuple{Elements: elements}
 	case *my_ast.IndexExpression:
 		left := Eval(node.Left, env)
 		if isError(left) {
 			return left
 		}
+		if node.Optional && isNull(left) {
+			return NULL
+		}
 		return evalIndexExpression(left, node, env)
+	case *my_ast.MemberExpression:
+		left := Eval(node.Left, env)
+		if isError(left) {
+			return left
+		}
+		if node.Optional && isNull(left) {
+			return NULL
+		}
+		return evalMemberExpression(left, node.Property)
 	case *my_ast.HashExpression:
 		return evalHashExpression(node, env)
 	}
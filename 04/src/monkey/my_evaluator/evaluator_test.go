@@ -7,6 +7,7 @@ import (
 	"monkey/my_parser"
 	"reflect"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -58,7 +59,9 @@ func TestPrefixMinusOperator(t *testing.T) {
 	cases := []testCase{
 		{"-5", "-5"},
 		{"5", "5"},
-		{"-" + strconv.FormatUint(math.MaxUint64, 10), "1"},
+		{"-" + strconv.FormatUint(math.MaxUint64, 10), "-" + strconv.FormatUint(math.MaxUint64, 10)},
+		{"-9223372036854775808", "-9223372036854775808"},
+		{"--9223372036854775808", "9223372036854775808"},
 		{"-" + strconv.FormatUint(math.MaxInt64, 10), "-" + strconv.FormatUint(math.MaxInt64, 10)},
 		{"--" + strconv.FormatUint(math.MaxInt64, 10), strconv.FormatUint(math.MaxInt64, 10)},
 		{strconv.FormatFloat(1.234, 'f', -1, 64), "1.234"},
@@ -144,6 +147,9 @@ func TestLetStatements(t *testing.T) {
 		{"let a = 5; a;", 5, intType},
 		{"let a = 5*5; 5*a", 5 * 5 * 5, intType},
 		{"let a = 5; let b = a +5; b;", 10, intType},
+		{"let x1 = 2; let x2 = x1 * 3; x2 - x1", 4, intType},
+		{"let log2 = fn(x) { x }; log2(8)", 8, intType},
+		{"x9", "identifier not found: x9", errType},
 	}
 	testCaseWithStruct(t, tests)
 }
@@ -172,13 +178,27 @@ func TestFunctionEvaluation(t *testing.T) {
 
 func TestStringEvaluation(t *testing.T) {
 	tests := []*testCaseTyped{
-		{`"Hello\tWorld!\n"`, `Hello\tWorld!\n`, strType},
+		{`"Hello\tWorld!\n"`, "Hello\tWorld!\n", strType},
+		{"`Hello\\tWorld!\\n`", `Hello\tWorld!\n`, strType},
 		{"\"Hello\"+ \t\"World\"", "HelloWorld", strType},
 		{"'Hello'- \n'World'", "unknown operator: STRING-STRING", errType},
 	}
 	testCaseWithStruct(t, tests)
 }
 
+func TestInterpolatedString(t *testing.T) {
+	tests := []*testCaseTyped{
+		{`let name = "ann"; let age = 41; "hello ${name}, you are ${age + 1}"`, "hello ann, you are 42", strType},
+		{`"${1.5} ${null} ${true} ${[1, 2]} ${(1,)}"`, "1.5 null true [1,2] (1,)", strType},
+		{`"${"nested ${1 + 1}"}" == "nested 2"`, true, boolType},
+		{`"${ {"k": "v"}.k }"`, "v", strType},
+		{`"\${x}"`, "${x}", strType},
+		{`"${x}"`, "identifier not found: x", errType},
+		{`len("a${"bc"}")`, 3, intType},
+	}
+	testCaseWithStruct(t, tests)
+}
+
 func TestBuiltinLenFunction(t *testing.T) {
 	tests := []*testCaseTyped{
 		{`len("Hello\tWorld!\n")`, 13, intType},
@@ -188,6 +208,330 @@ func TestBuiltinLenFunction(t *testing.T) {
 	testCaseWithStruct(t, tests)
 }
 
+func TestStringRunes(t *testing.T) {
+	tests := []*testCaseTyped{
+		{`len("héllo")`, 5, intType},
+		{`"héllo"[4]`, "o", strType},
+		{`"héllo"[1]`, "é", strType},
+		{`"日本語"[-1]`, "語", strType},
+		{`"héllo"[1:3]`, "él", strType},
+		{`"héllo"[::-1]`, "olléh", strType},
+		{`"héllo"[::2]`, "hlo", strType},
+		{`"日本語"[3]`, "index 3 out of array with length 3", errType},
+		{`byteLen("héllo")`, 6, intType},
+		{`bytes("hé")`, []interface{}{104, 195, 169}, arrType},
+		{`fromBytes(bytes("日本語")) == "日本語"`, true, boolType},
+		{`fromBytes([104, 256])`, "fromBytes expecting bytes in 0..255, but got 256", errType},
+		{`fromBytes([195]) == "\xc3"`, true, boolType},
+		{`fromBytes(bytes("\xff")) == "\xff"`, true, boolType},
+		{`len(fromBytes([255, 104]))`, 2, intType},
+		{`bytes(fromBytes([0, 195, 255]))`, []interface{}{0, 195, 255}, arrType},
+		{`let s = fromBytes([255, 65]); s[::1] == s`, true, boolType},
+		{`bytes(fromBytes([255, 65])[0])`, []interface{}{255}, arrType},
+		{`"a\xffé"[1] == "\xff"`, true, boolType},
+		{`"a\xffé"[1:] == "\xffé"`, true, boolType},
+		{`"a\xffé"[::-1] == "é\xffa"`, true, boolType},
+		{`reverse("a\xc3é") == "é\xc3a"`, true, boolType},
+		{`chars("\xffb") == ["\xff", "b"]`, true, boolType},
+		{`byteLen(1)`, "argument to byteLen not supported: got INT", errType},
+		{`bytes("a", "b")`, "wrong number of arguments: got=2, want=1", errType},
+	}
+	testCaseWithStruct(t, tests)
+}
+
+func TestStringBuiltins(t *testing.T) {
+	tests := []*testCaseTyped{
+		{`split("a,b,,c", ",")`, []interface{}{"a", "b", "", "c"}, arrType},
+		{`split("hé", "")`, []interface{}{"h", "é"}, arrType},
+		{`join(["a", 1, null], "-")`, "a-1-null", strType},
+		{`join(split("a b", " "))`, "ab", strType},
+		{`join(("a", "b"), ",")`, "a,b", strType},
+		{`join(("a",))`, "a", strType},
+		{`join("ab", ",")`, "argument 1 to join not supported: got STRING", errType},
+		{`trim("  a b \n")`, "a b", strType},
+		{`trimLeft("xxaxx", "x")`, "axx", strType},
+		{`trimRight("xxaxx", "x")`, "xxa", strType},
+		{`upper("héllo")`, "HÉLLO", strType},
+		{`lower("ABC")`, "abc", strType},
+		{`replace("a.b.c", ".", "::")`, "a::b::c", strType},
+		{`contains("héllo", "él")`, true, boolType},
+		{`startsWith("héllo", "hé")`, true, boolType},
+		{`endsWith("héllo", "x")`, false, boolType},
+		{`indexOf("日本語", "語")`, 2, intType},
+		{`indexOf("abc", "d")`, -1, intType},
+		{`repeat("ab", 3)`, "ababab", strType},
+		{`repeat("ab", 0)`, "", strType},
+		{`repeat("ab", -1)`, "repeat expecting non-negative count, but got -1", errType},
+		{`padLeft("7", 3, "0")`, "007", strType},
+		{`padRight("é", 3) + "|"`, "é  |", strType},
+		{`padLeft("long", 2)`, "long", strType},
+		{`padLeft("a", 3, "ab")`, `padLeft expecting a single character to pad with, but got "ab"`, errType},
+		{`chars("hé")`, []interface{}{"h", "é"}, arrType},
+		{`format("%s is %d (%.2f) %v %v", "x", 42, 1.5, true, [1])`, "x is 42 (1.50) true [1]", strType},
+		{`format("%d", 18446744073709551616)`, "18446744073709551616", strType},
+		{`format("%5.1f%% of %x, %05d", 12, "hi", -42)`, " 12.0% of 6869, -0042", strType},
+		{`format("%d %s", 1)`, "wrong number of arguments: got=2, want=3", errType},
+		{`format("%d", 1, 2)`, "wrong number of arguments: got=3, want=2", errType},
+		{`format("%d", "x")`, "argument 2 to format not supported: got STRING", errType},
+		{`format("%s", 1)`, "argument 2 to format not supported: got INT", errType},
+		{`format("%t", null)`, "argument 2 to format not supported: got NULL", errType},
+		{`format("%y", 1)`, "format: unsupported verb %y", errType},
+		{`format("%*d", 1, 2)`, "format: unsupported verb %*", errType},
+		{`format("50%")`, `format: missing verb at end of "50%"`, errType},
+		{`upper(1)`, "argument to upper not supported: got INT", errType},
+		{`trim(1)`, "argument to trim not supported: got INT", errType},
+		{`split("a", 1)`, "argument 2 to split not supported: got INT", errType},
+		{`split("a")`, "wrong number of arguments: got=1, want=2", errType},
+		{`padLeft("a")`, "wrong number of arguments: got=1, want=2..3", errType},
+		{`format()`, "wrong number of arguments: got=0, want>=1", errType},
+	}
+	testCaseWithStruct(t, tests)
+}
+
+func TestCollectionBuiltins(t *testing.T) {
+	tests := []*testCaseTyped{
+		{`let a = [1, 2]; append(a, 3, 4)`, []interface{}{1, 2, 3, 4}, arrType},
+		{`let a = [1, 2]; append(a, 3); a`, []interface{}{1, 2}, arrType},
+		{`append(1, 2)`, "first argument to `append` must be ARRAY: got=INT", errType},
+		{`map([1, 2, 3], fn(x) { x * x })`, []interface{}{1, 4, 9}, arrType},
+		{`map((1, 2), len)`, "argument to len not supported: got INT", errType},
+		{`filter([1, 2, 3, 4], fn(x) { x % 2 == 0 })`, []interface{}{2, 4}, arrType},
+		{`reduce([1, 2, 3], fn(acc, x) { acc + x })`, 6, intType},
+		{`reduce([], fn(acc, x) { acc + x }, 10)`, 10, intType},
+		{`reduce([], fn(acc, x) { acc + x })`, "reduce of empty array with no initial value", errType},
+		{`each([1, 2], fn(x) { x })`, nil, nullType},
+		{`any([0, null, 3])`, true, boolType},
+		{`any([1, 2], fn(x) { x > 5 })`, false, boolType},
+		{`all([])`, true, boolType},
+		{`all([1, 2], fn(x) { x > 0 })`, true, boolType},
+		{`find([1, 2, 3], fn(x) { x > 1 })`, 2, intType},
+		{`find([1], fn(x) { x > 1 })`, nil, nullType},
+		{`sortBy([3, 1.5, 2])`, []interface{}{1.5, 2, 3}, arrType},
+		{`sortBy(["bb", "a", "cc"], fn(s) { len(s) })`, []interface{}{"a", "bb", "cc"}, arrType},
+		{`sortBy([(1, "b"), (0, "a"), (1, "a")], fn(x, y) { x[0] < y[0] }) == [(0, "a"), (1, "b"), (1, "a")]`, true, boolType},
+		{`sortBy([1, 2, 3], fn(x, y) { y - x })`, []interface{}{3, 2, 1}, arrType},
+		{`sortBy(["ccc", "a", "bb"], len)`, []interface{}{"a", "bb", "ccc"}, arrType},
+		{`sortBy([-3, 1, -2], math.abs)`, []interface{}{1, -2, -3}, arrType},
+		{`sortBy([1, 2], len)`, "argument to len not supported: got INT", errType},
+		{`sortBy([1, 2, 3], fn(x, y) { (y - x) * 18446744073709551616 })`, []interface{}{3, 2, 1}, arrType},
+		{`sortBy([2, 1], fn(x) { if (x == 1) { x + "a" } else { x } })`, "unknown operator: INT+STRING", errType},
+		{`sortBy([1, "a"])`, "cannot compare STRING with INT", errType},
+		{`sortBy([1, 2], fn(x, y) { "x" })`, "sortBy comparator must return INT or BOOLEAN: got STRING", errType},
+		{`zip([1, 2, 3], ("a", "b")) == [(1, "a"), (2, "b")]`, true, boolType},
+		{`enumerate(["a", "b"]) == [(0, "a"), (1, "b")]`, true, boolType},
+		{`flatten([1, [2, [3]], (4,)])`, []interface{}{1, 2, []interface{}{3}, 4}, arrType},
+		{`flatten([1, [2, [3]]], 2)`, []interface{}{1, 2, 3}, arrType},
+		{`uniq([1, 2, 1.0, "a", "a", [1], [1]])`, []interface{}{1, 2, "a", []interface{}{1}}, arrType},
+		{`reverse([1, 2, 3])`, []interface{}{3, 2, 1}, arrType},
+		{`reverse("héllo")`, "olléh", strType},
+		{`sum([1, 2, 3.5])`, 6.5, floatType},
+		{`sum([])`, 0, intType},
+		{`sum(["a"])`, "sum expecting numbers, but got STRING", errType},
+		{`min([3, 1, 2])`, 1, intType},
+		{`max(3, 9, 2)`, 9, intType},
+		{`max(["a", "c", "b"])`, "c", strType},
+		{`min([])`, "min of empty array", errType},
+		{`map([1], 2)`, "argument 2 to map not supported: got INT", errType},
+		{`filter(1, fn(x) { x })`, "argument 1 to filter not supported: got INT", errType},
+		{`map([1])`, "wrong number of arguments: got=1, want=2", errType},
+		{`map([1, 2], fn(x, y) { x })`, "wrong number of arguments: got=1, want=2", errType},
+	}
+	testCaseWithStruct(t, tests)
+}
+
+func TestHashBuiltins(t *testing.T) {
+	tests := []*testCaseTyped{
+		{`keys({"b": 1, "a": 2, 3: 3})`, []interface{}{"b", "a", 3}, arrType},
+		{`values({"b": 1, "a": 2})`, []interface{}{1, 2}, arrType},
+		{`items({"b": 1, "a": 2}) == [("b", 1), ("a", 2)]`, true, boolType},
+		{`has({"a": null}, "a")`, true, boolType},
+		{`has({"a": 1}, "b")`, false, boolType},
+		{`has({}, [1])`, "key type not hashable: ARRAY", errType},
+		{`get({"a": 1}, "a", 0)`, 1, intType},
+		{`get({"a": 1}, "b", 0)`, 0, intType},
+		{`get({"a": 1}, "b")`, nil, nullType},
+		{`let h = {"a": 1, "b": 2}; delete(h, "a")`, true, boolType},
+		{`let h = {"a": 1, "b": 2}; delete(h, "a"); delete(h, "a")`, false, boolType},
+		{`let h = {"a": 1, "b": 2}; delete(h, "a"); keys(h)`, []interface{}{"b"}, arrType},
+		{`delete(freeze({"a": 1}), "a")`, "cannot mutate a frozen value", errType},
+		{`merge({"a": 1, "b": 2}, {"b": 3, "c": 4}) == {"a": 1, "b": 3, "c": 4}`, true, boolType},
+		{`keys(merge({"a": 1, "b": 2}, {"c": 3, "a": 4}))`, []interface{}{"a", "b", "c"}, arrType},
+		{`let h = {"a": 1}; merge(h, {"a": 2}); h["a"]`, 1, intType},
+		{`size({"a": 1, "b": 2})`, 2, intType},
+		{`size([1])`, "argument to size not supported: got ARRAY", errType},
+		{`hash([("a", 1), ["b", 2], ("a", 3)]) == {"a": 3, "b": 2}`, true, boolType},
+		{`keys(hash(items({"x": 1, "y": 2})))`, []interface{}{"x", "y"}, arrType},
+		{`size(hash())`, 0, intType},
+		{`hash([(1, 2, 3)])`, "hash expecting pairs of key and value, but got (1, 2, 3)", errType},
+		{`hash([([1], 2)])`, "key type not hashable: ARRAY", errType},
+		{`get({"a": 1})`, "wrong number of arguments: got=1, want=2..3", errType},
+		{`merge({}, 1)`, "argument 2 to merge not supported: got INT", errType},
+	}
+	testCaseWithStruct(t, tests)
+}
+
+func TestMathModule(t *testing.T) {
+	tests := []*testCaseTyped{
+		{`math.sqrt(16)`, 4, floatType},
+		{`math.sqrt(true)`, 1, floatType},
+		{`math.pow(2, 10)`, 1024, intType},
+		{`math.pow(2, 100) == 1267650600228229401496703205376`, true, boolType},
+		{`math.pow(2, -1)`, 0.5, floatType},
+		{`math.pow(4, 0.5)`, 2, floatType},
+		{`math.pow(3, 10000000)`, "math.pow: result too large: 3**10000000", errType},
+		{`math.abs(-3)`, 3, intType},
+		{`math.abs(-2.5)`, 2.5, floatType},
+		{`math.abs(-9223372036854775807 - 1) == 9223372036854775808`, true, boolType},
+		{`math.floor(2.7)`, 2, intType},
+		{`math.ceil(2.1)`, 3, intType},
+		{`math.round(-2.5)`, -3, intType},
+		{`math.trunc(-2.7)`, -2, intType},
+		{`math.floor(7)`, 7, intType},
+		{`math.floor(1e20) == 100000000000000000000`, true, boolType},
+		{`math.floor(math.nan)`, "cannot convert nan to INT", errType},
+		{`math.log(math.e)`, 1, floatType},
+		{`math.log2(8)`, 3, floatType},
+		{`math.log10(1000)`, 3, floatType},
+		{`math.exp(0)`, 1, floatType},
+		{`math.sin(0) + math.cos(0)`, 1, floatType},
+		{`math.atan2(1, 1) * 4 == math.pi`, true, boolType},
+		{`math.min(3, 1.5, 2)`, 1.5, floatType},
+		{`math.max([1, 5, 2])`, 5, intType},
+		{`math.max((1, 18446744073709551616)) == 18446744073709551616`, true, boolType},
+		{`math.min(true, 2)`, 1, intType},
+		{`math.min("a", "b")`, "argument 1 to math.min not supported: got STRING", errType},
+		{`math.max(1, null)`, "argument 2 to math.max not supported: got NULL", errType},
+		{`math.max([1, "a"])`, "math.max expecting numbers, but got STRING", errType},
+		{`math.min("a")`, "argument to math.min not supported: got STRING", errType},
+		{`math.min([])`, "math.min of empty array", errType},
+		{`math.inf > 1e308`, true, boolType},
+		{`math.nan == math.nan`, false, boolType},
+		{`math.log(0) < 0`, true, boolType},
+		{`math.sqrt("4")`, "argument to math.sqrt not supported: got STRING", errType},
+		{`math.pow(2)`, "wrong number of arguments: got=1, want=2", errType},
+		{`math.sqrt2`, "unknown member: math.sqrt2", errType},
+		{`let m = math; m.tau`, "unknown member: math.tau", errType},
+		{`json.load("1")`, "unknown member: json.load", errType},
+		{`{"a": 1}.b`, nil, nullType},
+		{`let math = {"sqrt": 1}; math.sqrt`, 1, intType},
+		{`let math = {"sqrt": 1}; math.pi`, nil, nullType},
+		{`delete(math, "pi")`, "cannot mutate a frozen value", errType},
+	}
+	testCaseWithStruct(t, tests)
+
+	SetFloatMode(FloatStrict)
+	defer SetFloatMode(FloatIEEE)
+	testCaseWithStruct(t, []*testCaseTyped{
+		{`math.sqrt(-1)`, "math.sqrt: result not finite: nan", errType},
+		{`math.sqrt(math.inf)`, math.Inf(1), floatType},
+	})
+}
+
+func TestConversionBuiltins(t *testing.T) {
+	tests := []*testCaseTyped{
+		{`int("42")`, 42, intType},
+		{`int("-0x1F")`, -31, intType},
+		{`int("007")`, 7, intType},
+		{`int("18446744073709551616") == 18446744073709551616`, true, boolType},
+		{`int(-2.7)`, -2, intType},
+		{`int(true)`, 1, intType},
+		{`int(" 42")`, `cannot convert " 42" to INT`, errType},
+		{`int("4x")`, `cannot convert "4x" to INT`, errType},
+		{`int("1.5")`, `cannot convert "1.5" to INT`, errType},
+		{`int("--1")`, `cannot convert "--1" to INT`, errType},
+		{`int(null)`, "cannot convert NULL to INT", errType},
+		{`int(1.0 / 0)`, "cannot convert inf to INT", errType},
+		{`float("1.5e3")`, 1500, floatType},
+		{`float(2)`, 2, floatType},
+		{`float(false)`, 0, floatType},
+		{`float("1e400")`, `cannot convert "1e400" to FLOAT`, errType},
+		{`float("x")`, `cannot convert "x" to FLOAT`, errType},
+		{`float("-2")`, -2, floatType},
+		{`float(".5")`, 0.5, floatType},
+		{`float("+1E-3")`, 0.001, floatType},
+		{`float("1_0.5")`, `cannot convert "1_0.5" to FLOAT`, errType},
+		{`float("Infinity")`, `cannot convert "Infinity" to FLOAT`, errType},
+		{`float("inf")`, `cannot convert "inf" to FLOAT`, errType},
+		{`float("nan")`, `cannot convert "nan" to FLOAT`, errType},
+		{`float("0x1p4")`, `cannot convert "0x1p4" to FLOAT`, errType},
+		{`float(" 1.5")`, `cannot convert " 1.5" to FLOAT`, errType},
+		{`float("1.")`, `cannot convert "1." to FLOAT`, errType},
+		{`float("1e")`, `cannot convert "1e" to FLOAT`, errType},
+		{`str(42)`, "42", strType},
+		{`str(1.5) + str(null) + str([1, "a"])`, "1.5null[1,a]", strType},
+		{`str("a")`, "a", strType},
+		{`bool("true")`, true, boolType},
+		{`bool("false")`, false, boolType},
+		{`bool(0)`, false, boolType},
+		{`bool(0.5)`, true, boolType},
+		{`bool(null)`, false, boolType},
+		{`bool("yes")`, `cannot convert "yes" to BOOLEAN`, errType},
+		{`bool([])`, "cannot convert ARRAY to BOOLEAN", errType},
+		{`type(1)`, "INT", strType},
+		{`type(18446744073709551616)`, "INT", strType},
+		{`type("a")`, "STRING", strType},
+		{`type(fn(x) { x })`, "FUNCTION", strType},
+		{`type(len)`, "BUILTIN", strType},
+		{`type((1,))`, "TUPLE", strType},
+		{`type(null)`, "NULL", strType},
+		{`isInt(1)`, true, boolType},
+		{`isInt(1.0)`, false, boolType},
+		{`isNumber(1.0)`, true, boolType},
+		{`isString("a")`, true, boolType},
+		{`isNull(null)`, true, boolType},
+		{`isHash({})`, true, boolType},
+		{`isFunction(len)`, true, boolType},
+		{`isFunction(fn() { 1 })`, true, boolType},
+		{`isFunction(math)`, false, boolType},
+		{`int()`, "wrong number of arguments: got=0, want=1", errType},
+		{`type(1, 2)`, "wrong number of arguments: got=2, want=1", errType},
+	}
+	testCaseWithStruct(t, tests)
+}
+
+func TestJSONModule(t *testing.T) {
+	tests := []*testCaseTyped{
+		{`json.parse("[1, 2.5, true, null, \"a\"]")`, []interface{}{1, 2.5, true, nil, "a"}, arrType},
+		{`json.parse("{\"b\": 1, \"a\": {\"c\": [2]}}").a.c[0]`, 2, intType},
+		{`keys(json.parse("{\"b\": 1, \"a\": 2, \"c\": 3}"))`, []interface{}{"b", "a", "c"}, arrType},
+		{`json.parse("18446744073709551616") == 18446744073709551616`, true, boolType},
+		{`json.parse("1e2")`, 100, floatType},
+		{`json.parse("\"\\u00e9\"")`, "é", strType},
+		{`json.parse("[1] 2")`, "json.parse: invalid data after top-level value", errType},
+		{`json.parse("")`, "json.parse: unexpected EOF", errType},
+		{`json.parse(1)`, "argument to json.parse not supported: got INT", errType},
+		{`json.stringify({"b": [1, 2.5, 2.0, (true, null)], "a": "<\"é\">"})`, `{"b":[1,2.5,2.0,[true,null]],"a":"<\"é\">"}`, strType},
+		{`json.stringify(18446744073709551616)`, "18446744073709551616", strType},
+		{`json.stringify({"a": [1], "b": {}}, 2)`, "{\n  \"a\": [\n    1\n  ],\n  \"b\": {}\n}", strType},
+		{`json.stringify([1], "\t")`, "[\n\t1\n]", strType},
+		{`let v = {"x": [1, "a", null]}; json.parse(json.stringify(v)) == v`, true, boolType},
+		{`json.stringify({"f": fn(x) { x }})`, "json.stringify: cannot encode FUNCTION", errType},
+		{`json.stringify([len])`, "json.stringify: cannot encode BUILTIN", errType},
+		{`json.stringify({1: 2})`, "json.stringify: cannot encode hash key of type INT", errType},
+		{`json.stringify(1.0 / 0)`, "json.stringify: cannot encode inf", errType},
+		{`json.stringify(1, true)`, "argument 2 to json.stringify not supported: got BOOLEAN", errType},
+		{`json.stringify(1, -1)`, "json.stringify expecting indent in 0..10, but got -1", errType},
+		{`json.stringify([1], "x")`, `json.stringify expecting indent of spaces and tabs, but got "x"`, errType},
+		{`json.stringify("\xff")`, `json.stringify: cannot encode invalid UTF-8 in "\xff"`, errType},
+		{`json.stringify({"a\xc3": 1})`, `json.stringify: cannot encode invalid UTF-8 in "a\xc3"`, errType},
+	}
+	testCaseWithStruct(t, tests)
+	// NOTE: syntax errors are worded by encoding/json
+	for _, input := range []string{`json.parse("{\"a\": }")`, `json.parse("[1")`, `json.parse("{\"a\" 1}")`} {
+		result, ok := testEval(t, input).(*my_object.Error)
+		assert.True(t, ok && strings.HasPrefix(result.Message, "json.parse: "), "input: %s", input)
+	}
+
+	arr := &my_object.Array{}
+	arr.Elements = []my_object.Object{&my_object.Integer{Value: 1}, arr}
+	stringify, _ := modules["json"].Get(&my_object.String{Value: "stringify"})
+	result := stringify.Value.(*my_object.Builtin).Fn(arr)
+	assert.Equal(t, "json.stringify: cannot encode cyclic structure", result.(*my_object.Error).Message)
+	shared := &my_object.Array{Elements: []my_object.Object{}}
+	result = stringify.Value.(*my_object.Builtin).Fn(&my_object.Array{Elements: []my_object.Object{shared, shared}})
+	assert.Equal(t, "[[],[]]", result.(*my_object.String).Value)
+}
+
 func TestArrayEvaluation(t *testing.T) {
 	tests := []*testCaseTyped{
 		{"[1, 2*2, 3+3]", []interface{}{1, 4, 6}, arrType},
@@ -217,6 +561,13 @@ func TestArrayIndexExpression(t *testing.T) {
 		{"[1, 2, 3, 4][::-1]", []interface{}{4, 3, 2, 1}, arrType},
 		{"[1, 2, 3, 4][::-3]", []interface{}{4, 1}, arrType},
 		{"[1, 2, 3, 4][1::-3]", []interface{}{2}, arrType},
+		{"[1, 2][9223372036854775808]", "index 9223372036854775808 out of array with length 2", errType},
+		{"[1, 2][-9223372036854775809]", "index -9223372036854775809 out of array with length 2", errType},
+		{`"ab"[9223372036854775808]`, "index 9223372036854775808 out of array with length 2", errType},
+		{"[1, 2, 3, 4][1:9223372036854775808]", []interface{}{2, 3, 4}, arrType},
+		{"[1, 2, 3, 4][:-9223372036854775809]", []interface{}{}, arrType},
+		{"[1, 2, 3, 4][::9223372036854775808]", []interface{}{1}, arrType},
+		{"[1, 2, 3, 4][::-9223372036854775809]", []interface{}{4}, arrType},
 	}
 	testCaseWithStruct(t, tests)
 }
@@ -226,20 +577,24 @@ func TestHashExpression(t *testing.T) {
 	evaluated := testEval(t, input)
 	hashObj, hok := evaluated.(*my_object.Hash)
 	assert.True(t, hok)
-	expected := map[my_object.HashKey]int64{
-		(&my_object.String{Value: "one"}).HashKey():   1,
-		(&my_object.String{Value: "two"}).HashKey():   2,
-		(&my_object.String{Value: "three"}).HashKey(): 3,
-		(&my_object.Integer{Value: 4}).HashKey():      4,
-		(&my_object.Boolean{Value: true}).HashKey():   5,
-		(&my_object.Boolean{Value: false}).HashKey():  6,
-		(&my_object.Float{Value: 7.1}).HashKey():      7,
-	}
-	for ek, ev := range expected {
-		pair, ok := hashObj.Pairs[ek]
+	expected := []struct {
+		key   my_object.HashableObject
+		value int64
+	}{
+		{&my_object.String{Value: "one"}, 1},
+		{&my_object.String{Value: "two"}, 2},
+		{&my_object.String{Value: "three"}, 3},
+		{&my_object.Integer{Value: 4}, 4},
+		{&my_object.Boolean{Value: true}, 5},
+		{&my_object.Boolean{Value: false}, 6},
+		{&my_object.Float{Value: 7.1}, 7},
+	}
+	for _, e := range expected {
+		pair, ok := hashObj.Get(e.key)
 		assert.True(t, ok, "pair: %+v", pair)
-		assert.EqualValues(t, ev, pair.Value.(*my_object.Integer).Value)
+		assert.EqualValues(t, e.value, pair.Value.(*my_object.Integer).Value)
 	}
+	assert.Equal(t, `{"one": 1, "two": 2, "three": 3, 4: 4, true: 5, false: 6, 7.1: 7}`, hashObj.Inspect())
 }
 
 func TestHashIndexExpression(t *testing.T) {
@@ -304,3 +659,247 @@ func testEval(t *testing.T, input string) my_object.Object {
 	// fmt.Println(prog.String())
 	return Eval(prog, my_object.NewEnvironment())
 }
+
+func TestInspectFunctionName(t *testing.T) {
+	tests := []struct {
+		input  string
+		expect string
+	}{
+		{"let add = fn(x, y) { x + y }; add", "<fn add/2>"},
+		{"let add = fn(x, y) { x + y }; let plus = add; plus", "<fn add/2>"},
+		{"fn() { 1 }", "<fn anonymous/0>"},
+		{"let apply = fn(f) { let g = f; g }; apply(fn() { 1 })", "<fn anonymous/0>"},
+		{"let f = fn() { 1 }; let apply = fn(g) { let h = g; h }; apply(f); f", "<fn f/0>"},
+		{"len", "<builtin len>"},
+		{"math.sqrt", "<builtin math.sqrt>"},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expect, testEval(t, test.input).Inspect())
+	}
+}
+
+func TestBuiltinKeysValuesFunction(t *testing.T) {
+	tests := []*testCaseTyped{
+		{`keys({"b": 1, "a": 2, 3: 3, "b": 4})`, []interface{}{"b", "a", 3}, arrType},
+		{`values({"b": 1, "a": 2, 3: 3, "b": 4})`, []interface{}{4, 2, 3}, arrType},
+		{`keys({})`, []interface{}{}, arrType},
+		{`keys([1])`, "argument to keys not supported: got ARRAY", errType},
+		{`values({}, {})`, "wrong number of arguments: got=2, want=1", errType},
+	}
+	testCaseWithStruct(t, tests)
+}
+
+func TestTupleAndFreeze(t *testing.T) {
+	tests := []*testCaseTyped{
+		{`let p = (1, 2); {(1, 2): "a"}[p]`, "a", strType},
+		{`{(1, "x"): 1}[(1, "y")]`, nil, nullType},
+		{`(1, 2, 3)[1]`, 2, intType},
+		{`len((1, 2, 3))`, 3, intType},
+		{`{freeze([1, 2]): "a"}[freeze([1, 2])]`, "a", strType},
+		{`{freeze({"x": [1]}): "h"}[freeze({"x": [1]})]`, "h", strType},
+		{`{[1, 2]: "a"}`, "key type not hashable: ARRAY", errType},
+		{`{(1, [2]): "a"}`, "key type not hashable: TUPLE", errType},
+		{`{(1, freeze([2])): "a"}[(1, freeze([2]))]`, "a", strType},
+		{`{1: 2}[{}]`, "key type not hashable: HASH", errType},
+		{`freeze(1)`, 1, intType},
+	}
+	testCaseWithStruct(t, tests)
+
+	assert.Equal(t, "(2, 3)", testEval(t, "(1, 2, 3)[1:]").Inspect())
+	assert.Equal(t, "freeze([2])", testEval(t, "freeze([1, 2])[1:]").Inspect())
+	assert.Equal(t, "[1, 2]", testEval(t, "let a = [1, 2]; freeze(a); a").Inspect())
+}
+
+func TestStructuralComparison(t *testing.T) {
+	tests := []*testCaseTyped{
+		{`"a" == "a"`, true, boolType},
+		{`"a" != "a"`, false, boolType},
+		{`"a" == "b"`, false, boolType},
+		{`"a" < "b"`, true, boolType},
+		{`"ab" > "a"`, true, boolType},
+		{`"B" < "a"`, true, boolType},
+		{`"a" == 1`, false, boolType},
+		{`"a" != 1`, true, boolType},
+		{`[1] == [1]`, true, boolType},
+		{`[1, [2, "x"]] == [1, [2, "x"]]`, true, boolType},
+		{`[1, [2, "x"]] == [1, [2, "y"]]`, false, boolType},
+		{`[1] == [1, 2]`, false, boolType},
+		{`[1] == [1.0]`, true, boolType},
+		{`[1] == (1,)`, false, boolType},
+		{`(1, "a") == (1, "a")`, true, boolType},
+		{`{"a": 1, "b": [2]} == {"b": [2], "a": 1}`, true, boolType},
+		{`{"a": 1} == {"a": 2}`, false, boolType},
+		{`{"a": 1} != {"a": 1, "b": 2}`, true, boolType},
+		{`[{}["missing"]] == [{}["missing"]]`, true, boolType},
+		{`let f = fn() {}; [f] == [f]`, true, boolType},
+		{`[fn() {}] == [fn() {}]`, false, boolType},
+		{`[1, 2] < [1, 3]`, true, boolType},
+		{`[1, 2] < [1, 2, 0]`, true, boolType},
+		{`[2] > [1, 5]`, true, boolType},
+		{`[1, "b"] > [1, "a"]`, true, boolType},
+		{`[1.5] < [2]`, true, boolType},
+		{`[] < []`, false, boolType},
+		{`(1, 2) < (1, 3)`, true, boolType},
+		{`[1] < ["a"]`, "cannot compare INT with STRING", errType},
+		{`{} < {}`, "unknown operator: HASH<HASH", errType},
+		{`[1] < (1,)`, "unknown operator: ARRAY<TUPLE", errType},
+		{`"a" - "b"`, "unknown operator: STRING-STRING", errType},
+		{`[1] + [2]`, "unknown operator: ARRAY+ARRAY", errType},
+	}
+	testCaseWithStruct(t, tests)
+}
+
+func TestObjectsEqualCycles(t *testing.T) {
+	newCycle := func() *my_object.Hash {
+		h := my_object.NewHash()
+		h.Set(&my_object.String{Value: "self"}, h)
+		return h
+	}
+	a, b := newCycle(), newCycle()
+	assert.True(t, objectsEqual(a, b))
+	b.Set(&my_object.String{Value: "extra"}, TRUE)
+	assert.False(t, objectsEqual(a, b))
+
+	arr := &my_object.Array{}
+	arr.Elements = []my_object.Object{arr}
+	cmp, err := compareObjects(arr, &my_object.Array{Elements: []my_object.Object{arr}})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, cmp)
+}
+
+func TestNullExpression(t *testing.T) {
+	tests := []*testCaseTyped{
+		{`null`, nil, nullType},
+		{`null == null`, true, boolType},
+		{`null != null`, false, boolType},
+		{`null == 0`, false, boolType},
+		{`false != null`, true, boolType},
+		{`"" == null`, false, boolType},
+		{`[null] == [null]`, true, boolType},
+		{`!null`, true, boolType},
+		{`null < null`, "unknown operator: NULL<NULL", errType},
+		{`null > 1`, "unknown operator: NULL>INT", errType},
+		{`1 + null`, "unknown operator: INT+NULL", errType},
+		{`[null] < [1]`, "cannot compare NULL with INT", errType},
+		{`null ?? 1`, 1, intType},
+		{`0 ?? 1`, 0, intType},
+		{`false ?? 1`, false, boolType},
+		{`null ?? null ?? "c"`, "c", strType},
+		{`1 ?? undefined`, 1, intType},
+		{`null ?? undefined`, "identifier not found: undefined", errType},
+	}
+	testCaseWithStruct(t, tests)
+}
+
+func TestMemberExpression(t *testing.T) {
+	tests := []*testCaseTyped{
+		{`let h = {"a": {"b": 1}}; h.a.b`, 1, intType},
+		{`{"a": 1}.b`, nil, nullType},
+		{`let h = {"f": fn(x) { x * 2 }}; h.f(3)`, 6, intType},
+		{`let h = {"a": null}; h.a?.b`, nil, nullType},
+		{`let h = {}; h.a?.b?.c`, nil, nullType},
+		{`let h = {}; h.a?.b ?? "default"`, "default", strType},
+		{`let h = {"a": [1, 2]}; h?.a?[1]`, 2, intType},
+		{`null?[0]`, nil, nullType},
+		{`null?[undefined]`, nil, nullType},
+		{`[1, 2]?[0]`, 1, intType},
+		{`let h = {}; h.a.b`, "member access not supported: NULL", errType},
+		{`null[0]`, "index operator not supported: NULL", errType},
+		{`1.a`, "member access not supported: INT", errType},
+		{`[1]?.a`, "member access not supported: ARRAY", errType},
+	}
+	testCaseWithStruct(t, tests)
+}
+
+func TestBigIntegerPromotion(t *testing.T) {
+	tests := []struct {
+		input  string
+		expect string
+	}{
+		{"9223372036854775807 + 1", "9223372036854775808"},
+		{"-9223372036854775807 - 2", "-9223372036854775809"},
+		{"9223372036854775807 * 2", "18446744073709551614"},
+		{"-9223372036854775808 / -1", "9223372036854775808"},
+		{"-9223372036854775808 * -1", "9223372036854775808"},
+		{"18446744073709551615", "18446744073709551615"},
+		{"18446744073709551615 * 18446744073709551615", "340282366920938463426481119284349108225"},
+		{"18446744073709551615 / 3", "6148914691236517205"},
+		{"18446744073709551615 - 18446744073709551614", "1"},
+		{"(9223372036854775807 + 1) - 1", "9223372036854775807"},
+		{"-(9223372036854775807 + 1) / 7", "-1317624576693539401"},
+		{"18446744073709551615 + true", "18446744073709551616"},
+		{"18446744073709551616 * 0.5", "9223372036854776000"},
+		{"0.5 * 18446744073709551616", "9223372036854776000"},
+		{"18446744073709551615 > 9223372036854775807", "true"},
+		{"-18446744073709551615 < 1", "true"},
+		{"18446744073709551615 == 18446744073709551615", "true"},
+		{"18446744073709551616 == 18446744073709551616.0", "true"},
+		{"[18446744073709551615] == [18446744073709551615]", "true"},
+		{"[18446744073709551615] < [18446744073709551616]", "true"},
+		{`{18446744073709551615: "big"}[18446744073709551614 + 1]`, "big"},
+	}
+	for _, test := range tests {
+		evaluated := testEval(t, test.input)
+		assert.Equal(t, test.expect, evaluated.String(), "input: %s", test.input)
+	}
+
+	assert.IsType(t, mi, testEval(t, "(9223372036854775807 + 1) - 1"))
+	assert.IsType(t, &my_object.BigInteger{}, testEval(t, "9223372036854775807 + 1"))
+	assert.Equal(t, my_object.ObjectType(my_object.INTEGER_OBJ), testEval(t, "9223372036854775807 + 1").Type())
+}
+
+func TestDivisionByZero(t *testing.T) {
+	tests := []*testCaseTyped{
+		{"7 % 3", 1, intType},
+		{"-7 % 3", -1, intType},
+		{"7 % -3", 1, intType},
+		{"-9223372036854775808 % -1", 0, intType},
+		{"7.5 % 2", 1.5, floatType},
+		{"1 / 0", "division by zero", errType},
+		{"1 % 0", "division by zero", errType},
+		{"1 / false", "division by zero", errType},
+		{"18446744073709551616 / 0", "division by zero", errType},
+		{"18446744073709551616 % 0", "division by zero", errType},
+		{"18446744073709551617 % 2", 1, intType},
+		{"let f = fn(x) { 10 / x }; f(0)", "division by zero", errType},
+		{"let f = fn(x) { 10 / x }; [f(2), f(0)]", "division by zero", errType},
+	}
+	testCaseWithStruct(t, tests)
+}
+
+func TestFloatMode(t *testing.T) {
+	ieee := []struct {
+		input  string
+		expect string
+	}{
+		{"1.0 / 0", "inf"},
+		{"-1 / 0.0", "-inf"},
+		{"0.0 / 0", "nan"},
+		{"1.0 % 0", "nan"},
+		{"let x = 18446744073709551616 * 1.0; x * x * x * x * x * x * x * x * x * x * x * x * x * x * x * x * x", "inf"},
+		{"let nan = 0.0 / 0; nan == nan", "false"},
+		{"let nan = 0.0 / 0; nan != nan", "true"},
+		{"let nan = 0.0 / 0; [nan] == [nan]", "false"},
+		{"let nan = 0.0 / 0; nan < 1", "false"},
+		{"let nan = 0.0 / 0; nan > 1", "false"},
+		{"let inf = 1.0 / 0; inf > 18446744073709551616", "true"},
+		{"let inf = 1.0 / 0; inf == inf", "true"},
+		{"let inf = 1.0 / 0; inf - inf", "nan"},
+	}
+	assert.Equal(t, FloatIEEE, GetFloatMode())
+	for _, test := range ieee {
+		assert.Equal(t, test.expect, testEval(t, test.input).String(), "input: %s", test.input)
+	}
+
+	SetFloatMode(FloatStrict)
+	defer SetFloatMode(FloatIEEE)
+	strict := []*testCaseTyped{
+		{"1.0 / 0", "division by zero", errType},
+		{"0.0 / 0", "division by zero", errType},
+		{"1.0 % 0", "division by zero", errType},
+		{"1 / 0", "division by zero", errType},
+		{"let x = 18446744073709551616 * 1.0; x * x * x * x * x * x * x * x * x * x * x * x * x * x * x * x * x", "float overflow: 9.7453140114e+288*1.8446744073709552e+19", errType},
+		{"1.0 / 4", 0.25, floatType},
+	}
+	testCaseWithStruct(t, strict)
+}
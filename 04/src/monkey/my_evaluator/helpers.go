@@ -16,9 +16,14 @@ func isError(obj my_object.Object) bool {
 	return false
 }
 
+func isNull(obj my_object.Object) bool {
+	_, ok := obj.(*my_object.Null)
+	return ok
+}
+
 func tryUnwrapReturnValue(obj my_object.Object) my_object.Object {
 	if returnVal, ok := obj.(*my_object.ReturnValue); ok {
-		return returnVal
+		return returnVal.Value
 	}
 	return obj
 }
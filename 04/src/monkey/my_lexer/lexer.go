@@ -7,6 +7,23 @@ type Lexer struct {
 	position     int  // current position in input (points to current char)
 	readPosition int  // current reading position in input (after current char)
 	ch           byte // current char under examination
+	prevType     token.TokenType
+	interps      []interpolation // strings whose ${...} is being lexed, innermost last
+	pending      []pendingToken  // tokens read ahead, see readTripleInterpolation
+	end          int             // offset right after the last token returned by NextToken
+}
+
+// pendingToken: a token read ahead with the offset right after it
+type pendingToken struct {
+	tok token.Token
+	end int
+}
+
+// interpolation: a string literal suspended at ${ to lex the embedded expression
+type interpolation struct {
+	quote  byte
+	triple bool
+	depth  int // braces opened and not yet closed in the embedded expression
 }
 
 func New(input string) *Lexer {
@@ -16,6 +33,23 @@ func New(input string) *Lexer {
 }
 
 func (l *Lexer) NextToken() token.Token {
+	if len(l.pending) == 0 {
+		tok := l.nextToken()
+		if !l.opensTripleInterpolation(tok) {
+			l.end = l.offset()
+			l.prevType = tok.Type
+			return tok
+		}
+		l.pending = l.readTripleInterpolation(tok)
+	}
+	next := l.pending[0]
+	l.pending = l.pending[1:]
+	l.end = next.end
+	l.prevType = next.tok.Type
+	return next.tok
+}
+
+func (l *Lexer) nextToken() token.Token {
 	var tok token.Token
 
 	l.skipWhitespace()
@@ -47,6 +81,8 @@ func (l *Lexer) NextToken() token.Token {
 		tok = newToken(token.SLASH, l.ch)
 	case '*':
 		tok = newToken(token.ASTERISK, l.ch)
+	case '%':
+		tok = newToken(token.PERCENT, l.ch)
 	case '<':
 		tok = newToken(token.LT, l.ch)
 	case '>':
@@ -56,9 +92,24 @@ func (l *Lexer) NextToken() token.Token {
 	case ',':
 		tok = newToken(token.COMMA, l.ch)
 	case '{':
+		if n := len(l.interps); n > 0 {
+			l.interps[n-1].depth++
+		}
 		tok = newToken(token.LBRACE, l.ch)
 	case '}':
-		tok = newToken(token.RBRACE, l.ch)
+		n := len(l.interps)
+		switch {
+		case n > 0 && l.interps[n-1].depth == 0:
+			// end of the embedded expression, back to the string
+			in := l.interps[n-1]
+			l.interps = l.interps[:n-1]
+			tok = l.readStringPart(in.quote, in.triple, token.INTERP_END, token.INTERP_MID)
+		case n > 0:
+			l.interps[n-1].depth--
+			fallthrough
+		default:
+			tok = newToken(token.RBRACE, l.ch)
+		}
 	case '(':
 		tok = newToken(token.LPAREN, l.ch)
 	case ')':
@@ -69,15 +120,35 @@ func (l *Lexer) NextToken() token.Token {
 		tok = newToken(token.RBRACKET, l.ch)
 	case ':':
 		tok = newToken(token.COLON, l.ch)
+	case '.':
+		// .5 is a float unless the dot follows an operand as in a.b
+		if isDigit(l.peekChar()) && !endsOperand(l.prevType) {
+			return *l.readNumberWithDot()
+		}
+		tok = newToken(token.DOT, l.ch)
+	case '?':
+		switch l.peekChar() {
+		case '?':
+			l.readChar()
+			tok = token.Token{Type: token.NULLISH, Literal: token.NULLISH}
+		case '.':
+			l.readChar()
+			tok = token.Token{Type: token.OPT_DOT, Literal: token.OPT_DOT}
+		case '[':
+			l.readChar()
+			tok = token.Token{Type: token.OPT_LBRACKET, Literal: token.OPT_LBRACKET}
+		default:
+			tok = newToken(token.ILLEGAL, l.ch)
+		}
 	case 0:
 		tok.Literal = ""
 		tok.Type = token.EOF
 	case '"':
-		tok.Type = token.STRING
-		tok.Literal = l.readString('"')
+		tok = l.readString('"')
 	case '\'':
-		tok.Type = token.STRING
-		tok.Literal = l.readString('\'')
+		tok = l.readString('\'')
+	case '`':
+		tok = l.readRawString()
 	default:
 		if isLetter(l.ch) {
 			tok.Literal = l.readIdentifier()
@@ -97,6 +168,20 @@ func (l *Lexer) NextToken() token.Token {
 	return tok
 }
 
+// Position: offset in input right after the last token returned by NextToken,
+// also for tokens read ahead
+func (l *Lexer) Position() int {
+	return l.end
+}
+
+// offset: offset in input right after the last token read by nextToken
+func (l *Lexer) offset() int {
+	if l.position > len(l.input) {
+		return len(l.input)
+	}
+	return l.position
+}
+
 func (l *Lexer) skipWhitespace() {
 	for l.ch == ' ' || l.ch == '\t' || l.ch == '\n' || l.ch == '\r' {
 		l.readChar()
@@ -121,9 +206,10 @@ func (l *Lexer) peekChar() byte {
 	}
 }
 
+// readIdentifier: letters, then letters or digits as in log2
 func (l *Lexer) readIdentifier() string {
 	position := l.position
-	for isLetter(l.ch) {
+	for isLetter(l.ch) || isDigit(l.ch) {
 		l.readChar()
 	}
 	return l.input[position:l.position]
@@ -141,6 +227,15 @@ func isLetter(ch byte) bool {
 	return 'a' <= ch && ch <= 'z' || 'A' <= ch && ch <= 'Z' || ch == '_'
 }
 
+func endsOperand(t token.TokenType) bool {
+	switch t {
+	case token.IDENT, token.INT, token.FLOAT, token.STRING, token.TRUE, token.FALSE, token.NULL,
+		token.RPAREN, token.RBRACKET, token.RBRACE:
+		return true
+	}
+	return false
+}
+
 func isDigit(ch byte) bool {
 	return '0' <= ch && ch <= '9'
 }
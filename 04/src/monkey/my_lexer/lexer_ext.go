@@ -1,61 +1,342 @@
 package my_lexer
 
 import (
+	"fmt"
 	token "monkey/my_token"
+	"strconv"
 	"strings"
+	"unicode/utf8"
 )
 
+// readNumberWithDot: reads an INT in decimal, hex (0x), octal (0o) or binary (0b),
+// or a FLOAT like 1.5, .5, 1e9 or 1.5E-3; digits may be separated by single underscores;
+// a malformed literal is an ILLEGAL token whose Literal tells what is wrong with it
 func (l *Lexer) readNumberWithDot() *token.Token {
 	position := l.position
-	for isDigit(l.ch) {
-		l.readChar()
+	if l.ch == '0' {
+		if base, bok := numberBases[l.peekChar()]; bok {
+			l.readChar()
+			l.readChar()
+			n, ok := l.readDigits(base.isDigit, true)
+			if !ok {
+				return l.illegalNumber(position, "invalid underscore in "+base.name+" literal")
+			}
+			if n == 0 {
+				return l.illegalNumber(position, "missing digits in "+base.name+" literal")
+			}
+			return l.endNumber(position, token.INT, base.name)
+		}
+	}
+	tokType := token.TokenType(token.INT)
+	if _, ok := l.readDigits(isDigit, false); !ok {
+		return l.illegalNumber(position, "invalid underscore in number literal")
 	}
 	if isDot(l.ch) && isDigit(l.peekChar()) {
-		sb := strings.Builder{}
-		sb.WriteString(l.input[position:l.position])
-		sb.WriteRune(floatDot)
+		tokType = token.FLOAT
 		l.readChar()
-		position = l.position
-		for isDigit(l.ch) {
+		if _, ok := l.readDigits(isDigit, false); !ok {
+			return l.illegalNumber(position, "invalid underscore in number literal")
+		}
+	}
+	if l.ch == 'e' || l.ch == 'E' {
+		tokType = token.FLOAT
+		l.readChar()
+		if l.ch == '+' || l.ch == '-' {
 			l.readChar()
 		}
-		sb.WriteString(l.input[position:l.position])
-		return &token.Token{
-			Literal: sb.String(),
-			Type:    token.FLOAT,
+		n, ok := l.readDigits(isDigit, false)
+		if !ok {
+			return l.illegalNumber(position, "invalid underscore in number literal")
 		}
-	} else {
-		return &token.Token{
-			Literal: l.input[position:l.position],
-			Type:    token.INT,
+		if n == 0 {
+			return l.illegalNumber(position, "missing digits in exponent of number literal")
 		}
 	}
+	return l.endNumber(position, tokType, "number")
 }
 
-func (l *Lexer) readString(startQuote byte) string {
-	sb := &strings.Builder{}
+type numberBase struct {
+	name    string
+	isDigit func(byte) bool
+}
+
+var numberBases = map[byte]numberBase{
+	'x': {"hex", isHexDigit},
+	'X': {"hex", isHexDigit},
+	'o': {"octal", isOctalDigit},
+	'O': {"octal", isOctalDigit},
+	'b': {"binary", isBinaryDigit},
+	'B': {"binary", isBinaryDigit},
+}
+
+// readDigits: reads digits and single underscores between them,
+// returns the number of digits and false for a misplaced underscore
+func (l *Lexer) readDigits(valid func(byte) bool, leadingUnderscore bool) (int, bool) {
+	n := 0
 	for {
-		l.readChar()
-		if l.ch == startQuote || l.ch == 0 {
-			break
+		if l.ch == '_' {
+			if (n == 0 && !leadingUnderscore) || !valid(l.peekChar()) {
+				return n, false
+			}
+			l.readChar()
+			continue
+		}
+		if !valid(l.ch) {
+			return n, true
 		}
-		// all back slash rules go here
-		switch l.ch {
-		case '\\':
+		n++
+		l.readChar()
+	}
+}
+
+// endNumber: a letter or digit right after a literal makes it malformed, as in 0b12 or 1abc
+func (l *Lexer) endNumber(position int, tokType token.TokenType, kind string) *token.Token {
+	if isLetter(l.ch) || isDigit(l.ch) {
+		return l.illegalNumber(position, fmt.Sprintf("invalid character %q in %s literal", l.ch, kind))
+	}
+	return &token.Token{Type: tokType, Literal: l.input[position:l.position]}
+}
+
+// illegalNumber: skips the rest of a malformed literal and reports it with the reason
+func (l *Lexer) illegalNumber(position int, reason string) *token.Token {
+	for isLetter(l.ch) || isDigit(l.ch) {
+		l.readChar()
+	}
+	return &token.Token{
+		Type:    token.ILLEGAL,
+		Literal: reason + ": " + l.input[position:l.position],
+	}
+}
+
+func isHexDigit(ch byte) bool {
+	return isDigit(ch) || 'a' <= ch && ch <= 'f' || 'A' <= ch && ch <= 'F'
+}
+
+func isOctalDigit(ch byte) bool {
+	return '0' <= ch && ch <= '7'
+}
+
+func isBinaryDigit(ch byte) bool {
+	return ch == '0' || ch == '1'
+}
+
+// readString: reads a string quoted with startQuote, or with three of them
+// for a multi-line string whose indentation is stripped, see stripIndent;
+// an unterminated string or a bad escape sequence yields an ILLEGAL token
+func (l *Lexer) readString(startQuote byte) token.Token {
+	triple := l.peekChar() == startQuote && l.peekCharAt(2) == startQuote
+	if triple {
+		l.readChar()
+		l.readChar()
+	}
+	return l.readStringPart(startQuote, triple, token.STRING, token.INTERP_BEGIN)
+}
+
+// readStringPart: reads the text of a string up to its closing quote,
+// giving a whole token, or up to ${, giving an open token and
+// suspending the string until the } closing the embedded expression
+func (l *Lexer) readStringPart(quote byte, triple bool, whole, open token.TokenType) token.Token {
+	position := l.position + 1
+	for {
+		l.readChar()
+		switch {
+		case l.ch == 0 && l.position >= len(l.input):
+			return token.Token{Type: token.ILLEGAL, Literal: "unterminated string literal"}
+		case l.ch == '\\':
+			l.readChar()
+		case l.ch == '$' && l.peekChar() == '{':
+			raw := l.input[position:l.position]
 			l.readChar()
-			switch l.ch {
-			case 'r':
-				sb.WriteByte('\r')
-			case 't':
-				sb.WriteByte('\t')
-			case 'n':
-				sb.WriteByte('\n')
-			default:
-				sb.WriteByte(l.ch)
+			l.interps = append(l.interps, interpolation{quote: quote, triple: triple})
+			return stringToken(open, raw, triple)
+		case l.ch == quote && (!triple || l.peekChar() == quote && l.peekCharAt(2) == quote):
+			raw := l.input[position:l.position]
+			if triple {
+				l.readChar()
+				l.readChar()
 			}
+			return stringToken(whole, raw, triple)
+		}
+	}
+}
+
+// stringToken: unescapes the raw text of a string or a part of it;
+// parts of a triple-quoted string are left raw for readTripleInterpolation
+func stringToken(tokType token.TokenType, raw string, triple bool) token.Token {
+	if triple {
+		if tokType != token.STRING {
+			return token.Token{Type: tokType, Literal: raw}
+		}
+		raw = stripIndent(raw)
+	}
+	value, err := unescape(raw)
+	if err != nil {
+		return token.Token{Type: token.ILLEGAL, Literal: err.Error()}
+	}
+	return token.Token{Type: tokType, Literal: value}
+}
+
+func (l *Lexer) opensTripleInterpolation(tok token.Token) bool {
+	return tok.Type == token.INTERP_BEGIN && l.interps[len(l.interps)-1].triple
+}
+
+// indentPlaceholder: stands for an embedded expression while stripping
+// the indentation of the text parts of a triple-quoted string together
+const indentPlaceholder = "\x00"
+
+// readTripleInterpolation: reads ahead all tokens of the triple-quoted
+// string opened by begin, since the indentation to strip from its text parts
+// depends on all of them; nested ones are read the same way
+func (l *Lexer) readTripleInterpolation(begin token.Token) []pendingToken {
+	level := len(l.interps)
+	toks := []pendingToken{{begin, l.offset()}}
+	parts := []int{0}
+	for {
+		tok := pendingToken{l.nextToken(), l.offset()}
+		switch {
+		case tok.tok.Type == token.EOF:
+			// unterminated, the parser reports the missing INTERP_END
+			return append(toks, tok)
+		case len(l.interps) > level && l.opensTripleInterpolation(tok.tok):
+			toks = append(toks, l.readTripleInterpolation(tok.tok)...)
+			continue
+		case tok.tok.Type == token.INTERP_MID && len(l.interps) == level:
+			parts = append(parts, len(toks))
+		case tok.tok.Type == token.INTERP_END && len(l.interps) == level-1:
+			parts = append(parts, len(toks))
+			toks = append(toks, tok)
+			unescapeParts(toks, parts)
+			return toks
+		}
+		toks = append(toks, tok)
+	}
+}
+
+func unescapeParts(toks []pendingToken, parts []int) {
+	raws := make([]string, len(parts))
+	for idx, part := range parts {
+		raws[idx] = toks[part].tok.Literal
+	}
+	joined := strings.Join(raws, indentPlaceholder)
+	if strings.Count(joined, indentPlaceholder) == len(parts)-1 {
+		raws = strings.Split(stripIndent(joined), indentPlaceholder)
+	}
+	for idx, part := range parts {
+		toks[part].tok = stringToken(toks[part].tok.Type, raws[idx], false)
+	}
+}
+
+// readRawString: a `raw string` may span lines and has no escape sequences
+func (l *Lexer) readRawString() token.Token {
+	position := l.position + 1
+	for {
+		l.readChar()
+		if l.ch == 0 && l.position >= len(l.input) {
+			return token.Token{Type: token.ILLEGAL, Literal: "unterminated raw string literal"}
+		}
+		if l.ch == '`' {
+			return token.Token{Type: token.STRING, Literal: l.input[position:l.position]}
+		}
+	}
+}
+
+func (l *Lexer) peekCharAt(offset int) byte {
+	if l.position+offset >= len(l.input) {
+		return 0
+	}
+	return l.input[l.position+offset]
+}
+
+// stripIndent: drops a blank first line right after the opening quotes,
+// a blank last line holding the closing quotes,
+// and the leading whitespace common to all non-blank lines
+func stripIndent(raw string) string {
+	lines := strings.Split(raw, "\n")
+	if len(lines) > 1 && isBlank(lines[0]) {
+		lines = lines[1:]
+	}
+	if len(lines) > 1 && isBlank(lines[len(lines)-1]) {
+		lines = lines[:len(lines)-1]
+	}
+	indent := ""
+	first := true
+	for _, line := range lines {
+		if isBlank(line) {
+			continue
+		}
+		lead := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		if first {
+			indent, first = lead, false
+			continue
+		}
+		for !strings.HasPrefix(lead, indent) {
+			indent = indent[:len(indent)-1]
+		}
+	}
+	for idx, line := range lines {
+		if isBlank(line) {
+			lines[idx] = ""
+		} else {
+			lines[idx] = line[len(indent):]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func isBlank(line string) bool {
+	return strings.Trim(line, " \t\r") == ""
+}
+
+var simpleEscapes = map[byte]byte{
+	'n': '\n', 't': '\t', 'r': '\r', '0': 0,
+	'a': '\a', 'b': '\b', 'f': '\f', 'v': '\v',
+	'\\': '\\', '\'': '\'', '"': '"', '`': '`', '$': '$',
+}
+
+// escapeDigits: number of hex digits after \x, \u and \U
+var escapeDigits = map[byte]int{'x': 2, 'u': 4, 'U': 8}
+
+// unescape: replaces escape sequences in the raw text of a string literal;
+// besides the single character ones, \xNN is a byte,
+// \uNNNN and \UNNNNNNNN are unicode code points written as UTF-8
+func unescape(raw string) (string, error) {
+	if !strings.Contains(raw, "\\") {
+		return raw, nil
+	}
+	sb := &strings.Builder{}
+	for idx := 0; idx < len(raw); idx++ {
+		if raw[idx] != '\\' {
+			sb.WriteByte(raw[idx])
 			continue
 		}
-		sb.WriteByte(l.ch)
+		idx++
+		if idx >= len(raw) {
+			return "", fmt.Errorf("unterminated escape sequence in string literal")
+		}
+		if ch, ok := simpleEscapes[raw[idx]]; ok {
+			sb.WriteByte(ch)
+			continue
+		}
+		digits, ok := escapeDigits[raw[idx]]
+		if !ok {
+			return "", fmt.Errorf("unknown escape sequence in string literal: \\%c", raw[idx])
+		}
+		end := idx + 1 + digits
+		if end > len(raw) {
+			end = len(raw)
+		}
+		code, err := strconv.ParseUint(raw[idx+1:end], 16, 32)
+		if err != nil || end-idx-1 != digits {
+			return "", fmt.Errorf("invalid escape sequence in string literal: \\%s", raw[idx:end])
+		}
+		if raw[idx] == 'x' {
+			sb.WriteByte(byte(code))
+		} else if utf8.ValidRune(rune(code)) {
+			sb.WriteRune(rune(code))
+		} else {
+			return "", fmt.Errorf("invalid unicode code point in string literal: \\%s", raw[idx:end])
+		}
+		idx = end - 1
 	}
-	return sb.String()
+	return sb.String(), nil
 }
@@ -17,11 +17,78 @@ func TestFloatToken(t *testing.T) {
 		{Type: token.INT, Literal: "2"},
 		{Type: token.SEMICOLON, Literal: ";"},
 		{Type: token.INT, Literal: "1"},
-		{Type: token.ILLEGAL, Literal: "."},
+		{Type: token.DOT, Literal: "."},
 	}
 	testTokensWithInput(t, input, expect)
 }
 
+func TestNumberLiteralTokens(t *testing.T) {
+	input := ".5 0xFF 0o17 0b1010 1_000_000 1e9 1.5E-3 2e+2 0x_ff 1_0.2_5 007 1.a x.5"
+	expects := []*token.Token{
+		{Type: token.FLOAT, Literal: ".5"},
+		{Type: token.INT, Literal: "0xFF"},
+		{Type: token.INT, Literal: "0o17"},
+		{Type: token.INT, Literal: "0b1010"},
+		{Type: token.INT, Literal: "1_000_000"},
+		{Type: token.FLOAT, Literal: "1e9"},
+		{Type: token.FLOAT, Literal: "1.5E-3"},
+		{Type: token.FLOAT, Literal: "2e+2"},
+		{Type: token.INT, Literal: "0x_ff"},
+		{Type: token.FLOAT, Literal: "1_0.2_5"},
+		{Type: token.INT, Literal: "007"},
+		{Type: token.INT, Literal: "1"},
+		{Type: token.DOT, Literal: "."},
+		{Type: token.IDENT, Literal: "a"},
+		{Type: token.IDENT, Literal: "x"},
+		{Type: token.DOT, Literal: "."},
+		{Type: token.INT, Literal: "5"},
+	}
+	testTokensWithInput(t, input, expects)
+
+	malformed := []struct {
+		input  string
+		expect string
+	}{
+		{"0x", "missing digits in hex literal: 0x"},
+		{"0b;", "missing digits in binary literal: 0b"},
+		{"0b102", "invalid character '2' in binary literal: 0b102"},
+		{"0o8", "missing digits in octal literal: 0o8"},
+		{"0xfg", "invalid character 'g' in hex literal: 0xfg"},
+		{"1e", "missing digits in exponent of number literal: 1e"},
+		{"1e+", "missing digits in exponent of number literal: 1e+"},
+		{"1.5e", "missing digits in exponent of number literal: 1.5e"},
+		{"1__0", "invalid underscore in number literal: 1__0"},
+		{"1_", "invalid underscore in number literal: 1_"},
+		{"0x_", "invalid underscore in hex literal: 0x_"},
+		{"12abc", "invalid character 'a' in number literal: 12abc"},
+	}
+	for _, test := range malformed {
+		tok := New(test.input).NextToken()
+		assert.Equal(t, token.Token{Type: token.ILLEGAL, Literal: test.expect}, tok, "input: %s", test.input)
+	}
+}
+
+func TestNullishTokens(t *testing.T) {
+	input := "a?.b ?? null?[0]; h.k ? 1"
+	expects := []*token.Token{
+		{Type: token.IDENT, Literal: "a"},
+		{Type: token.OPT_DOT, Literal: "?."},
+		{Type: token.IDENT, Literal: "b"},
+		{Type: token.NULLISH, Literal: "??"},
+		{Type: token.NULL, Literal: "null"},
+		{Type: token.OPT_LBRACKET, Literal: "?["},
+		{Type: token.INT, Literal: "0"},
+		{Type: token.RBRACKET, Literal: "]"},
+		{Type: token.SEMICOLON, Literal: ";"},
+		{Type: token.IDENT, Literal: "h"},
+		{Type: token.DOT, Literal: "."},
+		{Type: token.IDENT, Literal: "k"},
+		{Type: token.ILLEGAL, Literal: "?"},
+		{Type: token.INT, Literal: "1"},
+	}
+	testTokensWithInput(t, input, expects)
+}
+
 func TestStringToken(t *testing.T) {
 	input := `
 "boo"
@@ -52,6 +119,59 @@ func TestStringTokenWithQuotes(t *testing.T) {
 	testTokensWithInput(t, input, expects)
 }
 
+func TestStringEscapes(t *testing.T) {
+	input := `"\x41\u00e9\U0001F600\0\\\'\"" '\a\b\f\v'`
+	expects := []*token.Token{
+		{Type: token.STRING, Literal: "Aé😀\x00\\'\""},
+		{Type: token.STRING, Literal: "\a\b\f\v"},
+	}
+	testTokensWithInput(t, input, expects)
+
+	malformed := []struct {
+		input  string
+		expect string
+	}{
+		{`"unclosed`, "unterminated string literal"},
+		{`'ends with \'`, "unterminated string literal"},
+		{"`raw", "unterminated raw string literal"},
+		{`"""never closed""`, "unterminated string literal"},
+		{`"\q"`, `unknown escape sequence in string literal: \q`},
+		{`"\x4"`, `invalid escape sequence in string literal: \x4`},
+		{`"\xZZ"`, `invalid escape sequence in string literal: \xZZ`},
+		{`"\uD800"`, `invalid unicode code point in string literal: \uD800`},
+		{`"\U00110000"`, `invalid unicode code point in string literal: \U00110000`},
+	}
+	for _, test := range malformed {
+		tok := New(test.input).NextToken()
+		assert.Equal(t, token.Token{Type: token.ILLEGAL, Literal: test.expect}, tok, "input: %s", test.input)
+	}
+}
+
+func TestRawAndTripleQuotedStrings(t *testing.T) {
+	input := "`C:\\new\\table\n  \"as is\"` x" + `
+let s = """
+    first
+      indented "quoted" \t
+    last
+    """;
+'''one line''' """ends with quote\""""
+"" x`
+	expects := []*token.Token{
+		{Type: token.STRING, Literal: "C:\\new\\table\n  \"as is\""},
+		{Type: token.IDENT, Literal: "x"},
+		{Type: token.LET, Literal: "let"},
+		{Type: token.IDENT, Literal: "s"},
+		{Type: token.ASSIGN, Literal: "="},
+		{Type: token.STRING, Literal: "first\n  indented \"quoted\" \t\nlast"},
+		{Type: token.SEMICOLON, Literal: ";"},
+		{Type: token.STRING, Literal: "one line"},
+		{Type: token.STRING, Literal: `ends with quote"`},
+		{Type: token.STRING, Literal: ""},
+		{Type: token.IDENT, Literal: "x"},
+	}
+	testTokensWithInput(t, input, expects)
+}
+
 func testTokensWithInput(t *testing.T, input string, expects []*token.Token) {
 	lexer := New(input)
 	for _, exp := range expects {
@@ -64,6 +184,16 @@ func testTokensWithInput(t *testing.T, input string, expects []*token.Token) {
 	}
 }
 
+func TestIdentifierWithDigits(t *testing.T) {
+	input := "log2 x_10y 2x"
+	expects := []*token.Token{
+		{Type: token.IDENT, Literal: "log2"},
+		{Type: token.IDENT, Literal: "x_10y"},
+		{Type: token.ILLEGAL, Literal: "invalid character 'x' in number literal: 2x"},
+	}
+	testTokensWithInput(t, input, expects)
+}
+
 func TestMapToken(t *testing.T) {
 	input := `
 {"boo":'foo'}; {boo: true}
@@ -83,3 +213,65 @@ func TestMapToken(t *testing.T) {
 	}
 	testTokensWithInput(t, input, expects)
 }
+
+func TestInterpolatedStringTokens(t *testing.T) {
+	input := `"a ${x} b ${ {"k": 1}["k"] }" '${"in ${y}"}\${z}'` + "\n" + `"""
+    ${x}
+      \t${y}
+    """ x`
+	expects := []*token.Token{
+		{Type: token.INTERP_BEGIN, Literal: "a "},
+		{Type: token.IDENT, Literal: "x"},
+		{Type: token.INTERP_MID, Literal: " b "},
+		{Type: token.LBRACE, Literal: "{"},
+		{Type: token.STRING, Literal: "k"},
+		{Type: token.COLON, Literal: ":"},
+		{Type: token.INT, Literal: "1"},
+		{Type: token.RBRACE, Literal: "}"},
+		{Type: token.LBRACKET, Literal: "["},
+		{Type: token.STRING, Literal: "k"},
+		{Type: token.RBRACKET, Literal: "]"},
+		{Type: token.INTERP_END, Literal: ""},
+		{Type: token.INTERP_BEGIN, Literal: ""},
+		{Type: token.INTERP_BEGIN, Literal: "in "},
+		{Type: token.IDENT, Literal: "y"},
+		{Type: token.INTERP_END, Literal: ""},
+		{Type: token.INTERP_END, Literal: "${z}"},
+		{Type: token.INTERP_BEGIN, Literal: ""},
+		{Type: token.IDENT, Literal: "x"},
+		{Type: token.INTERP_MID, Literal: "\n  \t"},
+		{Type: token.IDENT, Literal: "y"},
+		{Type: token.INTERP_END, Literal: ""},
+		{Type: token.IDENT, Literal: "x"},
+	}
+	testTokensWithInput(t, input, expects)
+
+	l := New(`"a ${x`)
+	for _, expect := range []token.TokenType{token.INTERP_BEGIN, token.IDENT, token.EOF} {
+		assert.Equal(t, expect, l.NextToken().Type)
+	}
+}
+
+func TestPositionAfterTokens(t *testing.T) {
+	// NOTE: tokens of triple-quoted strings are read ahead, see readTripleInterpolation
+	input := "y = \"\"\"\n  a ${x} b ${'''${z}'''}\n  \"\"\" + 1"
+	expects := []string{
+		"y",
+		"y =",
+		"y = \"\"\"\n  a ${",
+		"y = \"\"\"\n  a ${x",
+		"y = \"\"\"\n  a ${x} b ${",
+		"y = \"\"\"\n  a ${x} b ${'''${",
+		"y = \"\"\"\n  a ${x} b ${'''${z",
+		"y = \"\"\"\n  a ${x} b ${'''${z}'''",
+		"y = \"\"\"\n  a ${x} b ${'''${z}'''}\n  \"\"\"",
+		"y = \"\"\"\n  a ${x} b ${'''${z}'''}\n  \"\"\" +",
+		input,
+		input,
+	}
+	l := New(input)
+	for _, expect := range expects {
+		tok := l.NextToken()
+		assert.Equal(t, expect, input[:l.Position()], "token: %+v", tok)
+	}
+}
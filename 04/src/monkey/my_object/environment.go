@@ -1,6 +1,14 @@
 package my_object
 
+import (
+	"sort"
+	"sync"
+)
+
+// Environment is safe for concurrent use,
+// so that closures sharing one can be called from multiple goroutines
 type Environment struct {
+	mu     sync.RWMutex
 	values map[string]Object
 	outie  *Environment
 }
@@ -12,7 +20,9 @@ func NewEnclosedEnvironment(outie *Environment) *Environment {
 }
 
 func (e *Environment) Get(name string) (Object, bool) {
+	e.mu.RLock()
 	obj, ok := e.values[name]
+	e.mu.RUnlock()
 	if !ok && e.outie != nil {
 		obj, ok = e.outie.Get(name)
 	}
@@ -20,6 +30,26 @@ func (e *Environment) Get(name string) (Object, bool) {
 }
 
 func (e *Environment) Set(name string, value Object) Object {
+	e.mu.Lock()
+	defer e.mu.Unlock()
 	e.values[name] = value
 	return value
 }
+
+// Names: sorted names of all bindings visible from this environment
+func (e *Environment) Names() []string {
+	seen := map[string]bool{}
+	for env := e; env != nil; env = env.outie {
+		env.mu.RLock()
+		for name := range env.values {
+			seen[name] = true
+		}
+		env.mu.RUnlock()
+	}
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,114 @@
+package my_object
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash/fnv"
+)
+
+var ErrFrozen = errors.New("cannot mutate a frozen value")
+
+// AsHashable: obj as a hash key if it can be one;
+// tuples are hashable if all elements are,
+// arrays and hashes only if frozen and all elements or values are
+func AsHashable(obj Object) (HashableObject, bool) {
+	hashable, ok := obj.(HashableObject)
+	if !ok || !isHashable(obj) {
+		return nil, false
+	}
+	return hashable, true
+}
+
+func isHashable(obj Object) bool {
+	switch obj := obj.(type) {
+	case *Tuple:
+		return allHashable(obj.Elements)
+	case *Array:
+		return obj.Frozen && allHashable(obj.Elements)
+	case *Hash:
+		if !obj.Frozen {
+			return false
+		}
+		for _, pair := range obj.pairs {
+			if !isHashable(pair.Value) {
+				return false
+			}
+		}
+		return true
+	default:
+		_, ok := obj.(HashableObject)
+		return ok
+	}
+}
+
+func allHashable(objs []Object) bool {
+	for _, obj := range objs {
+		if !isHashable(obj) {
+			return false
+		}
+	}
+	return true
+}
+
+// Freeze: a deep copy of obj with arrays and hashes frozen,
+// tuples have their elements frozen, other objects are returned as they are
+func Freeze(obj Object) Object {
+	switch obj := obj.(type) {
+	case *Array:
+		if obj.Frozen {
+			return obj
+		}
+		return &Array{Elements: freezeAll(obj.Elements), Frozen: true}
+	case *Tuple:
+		return &Tuple{Elements: freezeAll(obj.Elements)}
+	case *Hash:
+		if obj.Frozen {
+			return obj
+		}
+		frozen := NewHash()
+		for _, pair := range obj.pairs {
+			frozen.Set(pair.Key.(HashableObject), Freeze(pair.Value))
+		}
+		frozen.Frozen = true
+		return frozen
+	default:
+		return obj
+	}
+}
+
+func freezeAll(objs []Object) []Object {
+	frozen := make([]Object, 0, len(objs))
+	for _, obj := range objs {
+		frozen = append(frozen, Freeze(obj))
+	}
+	return frozen
+}
+
+// combineHashKeys: HashKey of a composite key from its elements in order;
+// elements must be hashable
+func combineHashKeys(t ObjectType, objs []Object) HashKey {
+	h := fnv.New64a()
+	buf := make([]byte, 8)
+	for _, obj := range objs {
+		hk := obj.(HashableObject).HashKey()
+		h.Write([]byte(hk.Type))
+		binary.LittleEndian.PutUint64(buf, hk.Value)
+		h.Write(buf)
+	}
+	return HashKey{Type: t, Value: h.Sum64()}
+}
+
+// elementsKeyEqual: if both have the same keys in the same order
+func elementsKeyEqual(a, b []Object) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for idx := range a {
+		ak, aok := a[idx].(HashableObject)
+		bk, bok := b[idx].(HashableObject)
+		if !aok || !bok || ak.HashKey() != bk.HashKey() || !ak.KeyEquals(bk) {
+			return false
+		}
+	}
+	return true
+}
@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"hash/fnv"
 	"math"
+	"math/big"
 	"monkey/my_ast"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 )
 
 type ObjectType string
@@ -24,13 +26,19 @@ const (
 	BUILTIN_OBJ          = "BUILTIN"
 	ARRAY_OBJ            = "ARRAY"
 	HASH_OBJ             = "HASH"
+	TUPLE_OBJ            = "TUPLE"
 )
 
 type Object interface {
 	Type() ObjectType
+	// String: plain form for display, e.g. by `put`
 	String() string
+	// Inspect: unambiguous form, a re-parseable literal where possible
+	Inspect() string
 }
 
+// HashKey: hash value of a key; different keys may share the same HashKey,
+// so HashableObject.KeyEquals is checked on lookups too
 type HashKey struct {
 	Type  ObjectType
 	Value uint64
@@ -39,6 +47,8 @@ type HashKey struct {
 type HashableObject interface {
 	Object
 	HashKey() HashKey
+	// KeyEquals: if other is the same key, only called with equal HashKey
+	KeyEquals(other HashableObject) bool
 }
 
 type UnsignedInteger struct {
@@ -53,10 +63,17 @@ func (i *UnsignedInteger) String() string {
 	return strconv.FormatUint(i.Value, 10)
 }
 
+func (i *UnsignedInteger) Inspect() string { return i.String() }
+
 func (i *UnsignedInteger) HashKey() HashKey {
 	return HashKey{Type: i.Type(), Value: i.Value}
 }
 
+func (i *UnsignedInteger) KeyEquals(other HashableObject) bool {
+	o, ok := other.(*UnsignedInteger)
+	return ok && o.Value == i.Value
+}
+
 type Integer struct {
 	Value int64
 }
@@ -69,10 +86,45 @@ func (i *Integer) String() string {
 	return strconv.FormatInt(i.Value, 10)
 }
 
+func (i *Integer) Inspect() string { return i.String() }
+
 func (i *Integer) HashKey() HashKey {
 	return HashKey{Type: i.Type(), Value: uint64(i.Value)}
 }
 
+func (i *Integer) KeyEquals(other HashableObject) bool {
+	o, ok := other.(*Integer)
+	return ok && o.Value == i.Value
+}
+
+// BigInteger: an INT outside the int64 range, backed by math/big;
+// the evaluator only creates it for values that do not fit an Integer
+type BigInteger struct {
+	Value *big.Int
+}
+
+func (i *BigInteger) Type() ObjectType {
+	return INTEGER_OBJ
+}
+
+func (i *BigInteger) String() string {
+	return i.Value.String()
+}
+
+func (i *BigInteger) Inspect() string { return i.String() }
+
+func (i *BigInteger) HashKey() HashKey {
+	h := fnv.New64a()
+	h.Write([]byte{byte(i.Value.Sign() + 1)})
+	h.Write(i.Value.Bytes())
+	return HashKey{Type: i.Type(), Value: h.Sum64()}
+}
+
+func (i *BigInteger) KeyEquals(other HashableObject) bool {
+	o, ok := other.(*BigInteger)
+	return ok && o.Value.Cmp(i.Value) == 0
+}
+
 type Float struct {
 	Value float64
 }
@@ -81,14 +133,43 @@ func (f *Float) Type() ObjectType {
 	return FLOAT_OBJ
 }
 
+// String: inf, -inf and nan for values that are not finite
 func (f *Float) String() string {
+	switch {
+	case math.IsInf(f.Value, 1):
+		return "inf"
+	case math.IsInf(f.Value, -1):
+		return "-inf"
+	case math.IsNaN(f.Value):
+		return "nan"
+	}
 	return strconv.FormatFloat(f.Value, 'f', -1, 64)
 }
 
+// Inspect: always with a dot or an exponent so that it is parsed as float again
+func (f *Float) Inspect() string {
+	s := f.String()
+	if IsFiniteFloat(f.Value) && !strings.ContainsAny(s, ".eE") {
+		s += ".0"
+	}
+	return s
+}
+
+// IsFiniteFloat: whether f is neither inf nor nan
+func IsFiniteFloat(f float64) bool {
+	return !math.IsInf(f, 0) && !math.IsNaN(f)
+}
+
 func (f *Float) HashKey() HashKey {
 	return HashKey{Type: f.Type(), Value: math.Float64bits(f.Value)}
 }
 
+// KeyEquals: compare bits as HashKey does, so that nan works as a key
+func (f *Float) KeyEquals(other HashableObject) bool {
+	o, ok := other.(*Float)
+	return ok && math.Float64bits(o.Value) == math.Float64bits(f.Value)
+}
+
 type Boolean struct {
 	Value bool
 }
@@ -101,6 +182,8 @@ func (b *Boolean) String() string {
 	return strconv.FormatBool(b.Value)
 }
 
+func (b *Boolean) Inspect() string { return b.String() }
+
 func (b *Boolean) HashKey() HashKey {
 	if b.Value {
 		return HashKey{Type: b.Type(), Value: 1}
@@ -108,12 +191,19 @@ func (b *Boolean) HashKey() HashKey {
 	return HashKey{Type: b.Type(), Value: 0}
 }
 
+func (b *Boolean) KeyEquals(other HashableObject) bool {
+	o, ok := other.(*Boolean)
+	return ok && o.Value == b.Value
+}
+
 type Null struct{}
 
 func (n *Null) Type() ObjectType { return NULL_OBJ }
 
 func (n *Null) String() string { return "null" }
 
+func (n *Null) Inspect() string { return n.String() }
+
 type ReturnValue struct {
 	Value Object
 }
@@ -124,6 +214,10 @@ func (r *ReturnValue) String() string {
 	return r.Value.String()
 }
 
+func (r *ReturnValue) Inspect() string {
+	return r.Value.Inspect()
+}
+
 type Error struct {
 	Message string
 }
@@ -132,7 +226,10 @@ func (e *Error) Type() ObjectType { return ERROR_OBJ }
 
 func (e *Error) String() string { return "ERROR: " + e.Message }
 
+func (e *Error) Inspect() string { return e.String() }
+
 type Function struct {
+	Name       string // name of the first let binding, empty if anonymous
 	Parameters []*my_ast.Identifier
 	Body       *my_ast.BlockStatement
 	Env        *Environment
@@ -153,6 +250,15 @@ func (f *Function) String() string {
 	return sb.String()
 }
 
+// Inspect: name and arity, e.g. <fn add/2>
+func (f *Function) Inspect() string {
+	name := f.Name
+	if name == "" {
+		name = "anonymous"
+	}
+	return fmt.Sprintf("<fn %s/%d>", name, len(f.Parameters))
+}
+
 type String struct {
 	Value string
 }
@@ -164,16 +270,27 @@ func (s *String) String() string {
 	return s.Value
 }
 
+// Inspect: double quoted with escapes understood by the lexer
+func (s *String) Inspect() string {
+	return QuoteString(s.Value)
+}
+
 func (s *String) HashKey() HashKey {
 	h := fnv.New64a()
 	h.Write([]byte(s.Value))
 	return HashKey{Type: s.Type(), Value: h.Sum64()}
 }
 
+func (s *String) KeyEquals(other HashableObject) bool {
+	o, ok := other.(*String)
+	return ok && o.Value == s.Value
+}
+
 type BuiltinFunction func(args ...Object) Object
 
 type Builtin struct {
-	Fn BuiltinFunction
+	Name string
+	Fn   BuiltinFunction
 }
 
 func (b *Builtin) Type() ObjectType { return BUILTIN_OBJ }
@@ -182,8 +299,16 @@ func (b *Builtin) String() string {
 	return "builtin function"
 }
 
+func (b *Builtin) Inspect() string {
+	if b.Name == "" {
+		return "<builtin>"
+	}
+	return "<builtin " + b.Name + ">"
+}
+
 type Array struct {
 	Elements []Object
+	Frozen   bool // immutable and hashable if all elements are
 }
 
 func (a *Array) Type() ObjectType { return ARRAY_OBJ }
@@ -196,20 +321,236 @@ func (a *Array) String() string {
 	return "[" + strings.Join(elements, ",") + "]"
 }
 
+// Inspect: frozen arrays are shown as freeze([...])
+func (a *Array) Inspect() string {
+	elements := []string{}
+	for _, e := range a.Elements {
+		elements = append(elements, e.Inspect())
+	}
+	if a.Frozen {
+		return "freeze([" + strings.Join(elements, ", ") + "])"
+	}
+	return "[" + strings.Join(elements, ", ") + "]"
+}
+
+func (a *Array) HashKey() HashKey {
+	return combineHashKeys(a.Type(), a.Elements)
+}
+
+func (a *Array) KeyEquals(other HashableObject) bool {
+	o, ok := other.(*Array)
+	return ok && elementsKeyEqual(a.Elements, o.Elements)
+}
+
+// Tuple: an immutable sequence, hashable if all elements are
+type Tuple struct {
+	Elements []Object
+}
+
+func (t *Tuple) Type() ObjectType { return TUPLE_OBJ }
+
+func (t *Tuple) String() string {
+	elements := []string{}
+	for _, e := range t.Elements {
+		elements = append(elements, e.String())
+	}
+	if len(elements) == 1 {
+		return "(" + elements[0] + ",)"
+	}
+	return "(" + strings.Join(elements, ",") + ")"
+}
+
+func (t *Tuple) Inspect() string {
+	elements := []string{}
+	for _, e := range t.Elements {
+		elements = append(elements, e.Inspect())
+	}
+	if len(elements) == 1 {
+		return "(" + elements[0] + ",)"
+	}
+	return "(" + strings.Join(elements, ", ") + ")"
+}
+
+func (t *Tuple) HashKey() HashKey {
+	return combineHashKeys(t.Type(), t.Elements)
+}
+
+func (t *Tuple) KeyEquals(other HashableObject) bool {
+	o, ok := other.(*Tuple)
+	return ok && elementsKeyEqual(t.Elements, o.Elements)
+}
+
 type HashPair struct {
 	Key   Object
 	Value Object
 }
+
+// Hash keeps pairs in insertion order for printing and iteration;
+// updating the value of an existing key keeps its position;
+// pairs are bucketed by HashKey and told apart by KeyEquals in a bucket
 type Hash struct {
-	Pairs map[HashKey]HashPair
+	buckets map[HashKey][]*HashPair
+	pairs   []*HashPair // in insertion order
+	Frozen  bool        // immutable and hashable if all values are
+}
+
+func NewHash() *Hash {
+	return &Hash{buckets: map[HashKey][]*HashPair{}, pairs: []*HashPair{}}
 }
 
 func (h *Hash) Type() ObjectType { return HASH_OBJ }
 
 func (h *Hash) String() string {
 	pairs := []string{}
-	for _, pair := range h.Pairs {
+	for _, pair := range h.Pairs() {
 		pairs = append(pairs, fmt.Sprintf("%s:%s", pair.Key.String(), pair.Value.String()))
 	}
 	return "{" + strings.Join(pairs, ",") + "}"
 }
+
+// Inspect: frozen hashes are shown as freeze({...})
+func (h *Hash) Inspect() string {
+	pairs := []string{}
+	for _, pair := range h.Pairs() {
+		pairs = append(pairs, pair.Key.Inspect()+": "+pair.Value.Inspect())
+	}
+	if h.Frozen {
+		return "freeze({" + strings.Join(pairs, ", ") + "})"
+	}
+	return "{" + strings.Join(pairs, ", ") + "}"
+}
+
+// HashKey: independent of the order of pairs
+func (h *Hash) HashKey() HashKey {
+	sum := uint64(0)
+	for _, pair := range h.pairs {
+		sum += combineHashKeys(h.Type(), []Object{pair.Key, pair.Value}).Value
+	}
+	return HashKey{Type: h.Type(), Value: sum}
+}
+
+func (h *Hash) KeyEquals(other HashableObject) bool {
+	o, ok := other.(*Hash)
+	if !ok || o.Len() != h.Len() {
+		return false
+	}
+	for _, pair := range h.pairs {
+		otherPair, ok := o.Get(pair.Key.(HashableObject))
+		if !ok || !elementsKeyEqual([]Object{pair.Value}, []Object{otherPair.Value}) {
+			return false
+		}
+	}
+	return true
+}
+
+// Len: number of pairs
+func (h *Hash) Len() int { return len(h.pairs) }
+
+// Get: the pair with key, false if absent
+func (h *Hash) Get(key HashableObject) (HashPair, bool) {
+	if pair := h.lookup(key); pair != nil {
+		return *pair, true
+	}
+	return HashPair{}, false
+}
+
+// Set: add or update the pair with key, ErrFrozen if frozen
+func (h *Hash) Set(key HashableObject, value Object) error {
+	if h.Frozen {
+		return ErrFrozen
+	}
+	if pair := h.lookup(key); pair != nil {
+		pair.Value = value
+		return nil
+	}
+	if h.buckets == nil {
+		h.buckets = map[HashKey][]*HashPair{}
+	}
+	pair := &HashPair{Key: key, Value: value}
+	hk := key.HashKey()
+	h.buckets[hk] = append(h.buckets[hk], pair)
+	h.pairs = append(h.pairs, pair)
+	return nil
+}
+
+// Delete: remove the pair with key, false if absent, ErrFrozen if frozen
+func (h *Hash) Delete(key HashableObject) (bool, error) {
+	if h.Frozen {
+		return false, ErrFrozen
+	}
+	pair := h.lookup(key)
+	if pair == nil {
+		return false, nil
+	}
+	hk := key.HashKey()
+	h.buckets[hk] = removePair(h.buckets[hk], pair)
+	if len(h.buckets[hk]) == 0 {
+		delete(h.buckets, hk)
+	}
+	h.pairs = removePair(h.pairs, pair)
+	return true, nil
+}
+
+// Pairs: all pairs in insertion order
+func (h *Hash) Pairs() []HashPair {
+	pairs := make([]HashPair, 0, len(h.pairs))
+	for _, pair := range h.pairs {
+		pairs = append(pairs, *pair)
+	}
+	return pairs
+}
+
+func (h *Hash) lookup(key HashableObject) *HashPair {
+	for _, pair := range h.buckets[key.HashKey()] {
+		if key.KeyEquals(pair.Key.(HashableObject)) {
+			return pair
+		}
+	}
+	return nil
+}
+
+func removePair(pairs []*HashPair, pair *HashPair) []*HashPair {
+	for idx, p := range pairs {
+		if p == pair {
+			return append(pairs[:idx], pairs[idx+1:]...)
+		}
+	}
+	return pairs
+}
+
+// QuoteString: s in double quotes, escaping quotes, backslashes
+// and control characters the way string literals are written
+func QuoteString(s string) string {
+	sb := &strings.Builder{}
+	sb.WriteByte('"')
+	for idx := 0; idx < len(s); {
+		r, width := utf8.DecodeRuneInString(s[idx:])
+		if r == utf8.RuneError && width == 1 {
+			// NOTE: invalid utf-8 byte kept as it is
+			fmt.Fprintf(sb, `\x%02x`, s[idx])
+			idx += width
+			continue
+		}
+		idx += width
+		switch r {
+		case '"':
+			sb.WriteString(`\"`)
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\t':
+			sb.WriteString(`\t`)
+		case '\r':
+			sb.WriteString(`\r`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(sb, `\x%02x`, r)
+			} else {
+				sb.WriteRune(r)
+			}
+		}
+	}
+	sb.WriteByte('"')
+	return sb.String()
+}
@@ -0,0 +1,153 @@
+package my_object
+
+import (
+	"math"
+	"monkey/my_ast"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInspect(t *testing.T) {
+	str := &String{Value: "say \"hi\"\n\t\\ \x01 é"}
+	hash := NewHash()
+	hash.Set(&String{Value: "k"}, &Array{})
+	hash.Set(&Integer{Value: 1}, &Float{Value: 2})
+	tests := []struct {
+		obj    Object
+		expect string
+	}{
+		{&Integer{Value: -1}, "-1"},
+		{&Float{Value: 2}, "2.0"},
+		{&Float{Value: 2.5}, "2.5"},
+		{&Float{Value: math.Inf(1)}, "inf"},
+		{&Float{Value: math.Inf(-1)}, "-inf"},
+		{&Float{Value: math.NaN()}, "nan"},
+		{&Boolean{Value: true}, "true"},
+		{&Null{}, "null"},
+		{str, `"say \"hi\"\n\t\\ \x01 é"`},
+		{&String{Value: "\xff"}, `"\xff"`},
+		{&Array{Elements: []Object{&Integer{Value: 1}, &String{Value: "1"}}}, `[1, "1"]`},
+		{hash, `{"k": [], 1: 2.0}`},
+		{&Function{Parameters: []*my_ast.Identifier{{Value: "x"}}}, "<fn anonymous/1>"},
+		{&Function{Name: "add", Parameters: []*my_ast.Identifier{{Value: "x"}, {Value: "y"}}}, "<fn add/2>"},
+		{&Builtin{Name: "len"}, "<builtin len>"},
+		{&ReturnValue{Value: str}, str.Inspect()},
+		{&Error{Message: "boom"}, "ERROR: boom"},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expect, test.obj.Inspect())
+	}
+	// plain form stays unquoted
+	assert.Equal(t, "say \"hi\"\n\t\\ \x01 é", str.String())
+}
+
+func TestHashOrder(t *testing.T) {
+	hash := &Hash{}
+	for _, k := range []string{"c", "a", "b", "d"} {
+		hash.Set(&String{Value: k}, &String{Value: k})
+	}
+	hash.Set(&String{Value: "a"}, &Integer{Value: 1})
+	deleted, err := hash.Delete(&String{Value: "b"})
+	assert.True(t, deleted)
+	assert.NoError(t, err)
+	deleted, err = hash.Delete(&String{Value: "b"})
+	assert.False(t, deleted)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, hash.Len())
+	assert.Equal(t, "{c:c,a:1,d:d}", hash.String())
+
+	pair, ok := hash.Get(&String{Value: "a"})
+	assert.True(t, ok)
+	assert.Equal(t, "1", pair.Value.String())
+	_, ok = hash.Get(&String{Value: "b"})
+	assert.False(t, ok)
+}
+
+// collidingKey: a key whose HashKey is the same for all values
+type collidingKey struct {
+	Value string
+}
+
+func (c *collidingKey) Type() ObjectType { return STRING_OBJ }
+func (c *collidingKey) String() string   { return c.Value }
+func (c *collidingKey) Inspect() string  { return c.Value }
+
+func (c *collidingKey) HashKey() HashKey { return HashKey{Type: STRING_OBJ, Value: 42} }
+
+func (c *collidingKey) KeyEquals(other HashableObject) bool {
+	o, ok := other.(*collidingKey)
+	return ok && o.Value == c.Value
+}
+
+func TestHashCollision(t *testing.T) {
+	a, b := &collidingKey{Value: "a"}, &collidingKey{Value: "b"}
+	assert.Equal(t, a.HashKey(), b.HashKey())
+
+	hash := NewHash()
+	hash.Set(a, &Integer{Value: 1})
+	hash.Set(b, &Integer{Value: 2})
+	assert.Equal(t, 2, hash.Len())
+	pair, ok := hash.Get(&collidingKey{Value: "a"})
+	assert.True(t, ok)
+	assert.Equal(t, "1", pair.Value.String())
+	pair, ok = hash.Get(b)
+	assert.True(t, ok)
+	assert.Equal(t, "2", pair.Value.String())
+
+	deleted, err := hash.Delete(a)
+	assert.True(t, deleted)
+	assert.NoError(t, err)
+	_, ok = hash.Get(a)
+	assert.False(t, ok)
+	pair, ok = hash.Get(b)
+	assert.True(t, ok)
+	assert.Equal(t, "2", pair.Value.String())
+
+	// same HashKey value but different types never collide
+	hash.Set(&Integer{Value: 42}, &Integer{Value: 3})
+	hash.Set(&Float{Value: 1}, &Integer{Value: 4})
+	_, ok = hash.Get(&Integer{Value: 1})
+	assert.False(t, ok)
+	assert.Equal(t, 3, hash.Len())
+}
+
+func TestHashableComposites(t *testing.T) {
+	one, two := &Integer{Value: 1}, &Integer{Value: 2}
+	tuple := &Tuple{Elements: []Object{one, &String{Value: "a"}}}
+	sameTuple := &Tuple{Elements: []Object{&Integer{Value: 1}, &String{Value: "a"}}}
+	_, ok := AsHashable(tuple)
+	assert.True(t, ok)
+	assert.Equal(t, tuple.HashKey(), sameTuple.HashKey())
+	assert.True(t, tuple.KeyEquals(sameTuple))
+	assert.NotEqual(t, tuple.HashKey(), (&Tuple{Elements: []Object{&String{Value: "a"}, one}}).HashKey())
+
+	array := &Array{Elements: []Object{one, two}}
+	_, ok = AsHashable(array)
+	assert.False(t, ok)
+	_, ok = AsHashable(&Tuple{Elements: []Object{array}})
+	assert.False(t, ok)
+	frozen := Freeze(&Tuple{Elements: []Object{array}})
+	_, ok = AsHashable(frozen)
+	assert.True(t, ok)
+	assert.False(t, array.Frozen, "freeze should copy")
+
+	h1, h2 := NewHash(), NewHash()
+	h1.Set(&String{Value: "x"}, one)
+	h1.Set(&String{Value: "y"}, array)
+	h2.Set(&String{Value: "y"}, Freeze(array))
+	h2.Set(&String{Value: "x"}, one)
+	f1, f2 := Freeze(h1).(*Hash), Freeze(h2).(*Hash)
+	_, ok = AsHashable(h1)
+	assert.False(t, ok)
+	_, ok = AsHashable(f1)
+	assert.True(t, ok)
+	assert.Equal(t, f1.HashKey(), f2.HashKey())
+	assert.True(t, f1.KeyEquals(f2))
+	assert.Equal(t, `freeze({"x": 1, "y": freeze([1, 2])})`, f1.Inspect())
+
+	assert.ErrorIs(t, f1.Set(&String{Value: "z"}, one), ErrFrozen)
+	_, err := f1.Delete(&String{Value: "x"})
+	assert.ErrorIs(t, err, ErrFrozen)
+	assert.Equal(t, 2, f1.Len())
+}
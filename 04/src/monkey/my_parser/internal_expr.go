@@ -1,10 +1,13 @@
 package my_parser
 
 import (
+	"errors"
 	"fmt"
+	"math/big"
 	"monkey/my_ast"
 	token "monkey/my_token"
 	"strconv"
+	"strings"
 )
 
 type PrecedenceLevel int
@@ -12,11 +15,12 @@ type PrecedenceLevel int
 const (
 	_ PrecedenceLevel = iota
 	LOWEST
+	NULLISH     // ??
 	EQUALS      // ==
 	LESSGREATER // > or <
 	SUM         // +
 	PREFIX      // -X or !X
-	PRODUCT     // *
+	PRODUCT     // * / %
 	CALL        // myFunction(X)
 	INDEX       // []
 	INDEXCOLON  // :
@@ -27,6 +31,7 @@ var InfixOperatorToPrecedences = map[my_ast.InfixOperator]PrecedenceLevel{
 	my_ast.INOP_PLUS:       SUM,
 	my_ast.INOP_ASTERISK:   PRODUCT,
 	my_ast.INOP_SLASH:      PRODUCT,
+	my_ast.INOP_PERCENT:    PRODUCT,
 	my_ast.INOP_LT:         LESSGREATER,
 	my_ast.INOP_GT:         LESSGREATER,
 	my_ast.INOP_EQ:         EQUALS,
@@ -34,6 +39,10 @@ var InfixOperatorToPrecedences = map[my_ast.InfixOperator]PrecedenceLevel{
 	my_ast.INOP_CALL:       CALL,
 	my_ast.INOP_INDEX:      INDEX,
 	my_ast.INOP_INDEXCOLON: INDEXCOLON,
+	my_ast.INOP_NULLISH:    NULLISH,
+	my_ast.INOP_DOT:        INDEX,
+	my_ast.INOP_OPT_DOT:    INDEX,
+	my_ast.INOP_OPT_INDEX:  INDEX,
 }
 
 func tokenPrecedenceLevel(t *token.Token) PrecedenceLevel {
@@ -78,8 +87,20 @@ func (p *Parser) parseIdentifier() my_ast.Expression {
 	}
 }
 
+// parseIntegerLiteral: 0x, 0o and 0b prefixes select the base,
+// other literals are decimal even with leading zeros
 func (p *Parser) parseIntegerLiteral() my_ast.Expression {
-	val, err := strconv.ParseUint(p.curToken.Literal, 10, 64)
+	digits := strings.ReplaceAll(p.curToken.Literal, "_", "")
+	base := 10
+	if len(digits) > 2 && digits[0] == '0' && strings.ContainsRune("xXoObB", rune(digits[1])) {
+		base = 0
+	}
+	val, err := strconv.ParseUint(digits, base, 64)
+	if errors.Is(err, strconv.ErrRange) {
+		if bigVal, ok := new(big.Int).SetString(digits, base); ok {
+			return &my_ast.BigInteger{Value: bigVal}
+		}
+	}
 	if err != nil {
 		p.appendError(fmt.Sprintf("cannot parse %s as uint :%v", p.curToken.Literal, err))
 		return nil
@@ -88,7 +109,7 @@ func (p *Parser) parseIntegerLiteral() my_ast.Expression {
 }
 
 func (p *Parser) parseFloatLiteral() my_ast.Expression {
-	val, err := strconv.ParseFloat(p.curToken.Literal, 64)
+	val, err := strconv.ParseFloat(strings.ReplaceAll(p.curToken.Literal, "_", ""), 64)
 	if err != nil {
 		p.appendError(fmt.Sprintf("cannot parse %s as float: %v", p.curToken.Literal, err))
 		return nil
@@ -109,6 +130,16 @@ func (p *Parser) parseBooleanLiteral() my_ast.Expression {
 	}
 }
 
+// parseIllegal: the lexer puts what is wrong into the literal of an ILLEGAL token
+func (p *Parser) parseIllegal() my_ast.Expression {
+	p.appendError(fmt.Sprintf("illegal token: %s", p.curToken.Literal))
+	return nil
+}
+
+func (p *Parser) parseNullLiteral() my_ast.Expression {
+	return &my_ast.Null{}
+}
+
 func (p *Parser) parsePrefixExpression() my_ast.Expression {
 	expr := &my_ast.PrefixExpression{
 		Operator: my_ast.PrefixOperator(p.curToken.Type),
@@ -130,8 +161,16 @@ func (p *Parser) parseInfixExpression(left my_ast.Expression) my_ast.Expression
 }
 
 func (p *Parser) parseGroupedExpression() my_ast.Expression {
+	// () is an empty tuple
+	if p.isPeekToken(token.RPAREN) {
+		p.nextToken()
+		return &my_ast.TupleExpression{Elements: []my_ast.Expression{}}
+	}
 	p.nextToken()
 	exp := p.parseExpression(LOWEST)
+	if p.isPeekToken(token.COMMA) {
+		return p.parseTupleExpression(exp)
+	}
 	if !p.isPeekToken(token.RPAREN) {
 		p.appendTokenError(token.RPAREN, p.peekToken)
 		p.nextToken()
@@ -141,6 +180,26 @@ func (p *Parser) parseGroupedExpression() my_ast.Expression {
 	return exp
 }
 
+// parseTupleExpression: (<EXPR>, <EXPR>, ...) after the first element,
+// a trailing comma is allowed so that (<EXPR>,) is a tuple with one element
+func (p *Parser) parseTupleExpression(first my_ast.Expression) my_ast.Expression {
+	tuple := &my_ast.TupleExpression{Elements: []my_ast.Expression{first}}
+	for p.isPeekToken(token.COMMA) {
+		p.nextToken()
+		if p.isPeekToken(token.RPAREN) {
+			break
+		}
+		p.nextToken()
+		tuple.Elements = append(tuple.Elements, p.parseExpression(LOWEST))
+	}
+	if !p.isPeekToken(token.RPAREN) {
+		p.appendTokenError(token.RPAREN, p.peekToken)
+		return nil
+	}
+	p.nextToken()
+	return tuple
+}
+
 func (p *Parser) parseIfExpression() my_ast.Expression {
 	// parse if condition as expression
 	p.nextToken()
@@ -252,6 +311,34 @@ func (p *Parser) parseStringExpression() my_ast.Expression {
 	}
 }
 
+// parseInterpolatedString: INTERP_BEGIN <EXPR> (INTERP_MID <EXPR>)* INTERP_END
+func (p *Parser) parseInterpolatedString() my_ast.Expression {
+	is := &my_ast.InterpolatedString{Texts: []string{p.curToken.Literal}}
+	for {
+		p.nextToken()
+		if p.isCurToken(token.INTERP_MID) || p.isCurToken(token.INTERP_END) {
+			p.appendError("empty expression in string interpolation")
+			return nil
+		}
+		expr := p.parseExpression(LOWEST)
+		if expr == nil {
+			return nil
+		}
+		is.Exprs = append(is.Exprs, expr)
+		p.nextToken()
+		switch p.curToken.Type {
+		case token.INTERP_MID:
+			is.Texts = append(is.Texts, p.curToken.Literal)
+		case token.INTERP_END:
+			is.Texts = append(is.Texts, p.curToken.Literal)
+			return is
+		default:
+			p.appendTokenError(token.INTERP_END, p.curToken)
+			return nil
+		}
+	}
+}
+
 func (p *Parser) parseArrayExpression() my_ast.Expression {
 	return &my_ast.ArrayExpression{Elements: p.parseExpressionList(token.RBRACKET)}
 }
@@ -280,6 +367,7 @@ func (p *Parser) parseExpressionList(end token.TokenType) []my_ast.Expression {
 func (p *Parser) parseIndexExpression(left my_ast.Expression) my_ast.Expression {
 	exp := &my_ast.IndexExpression{
 		Left:            left,
+		Optional:        p.isCurToken(token.OPT_LBRACKET),
 		StartIndex:      nil,
 		IsSetStartIndex: false,
 		EndIndex:        nil,
@@ -351,6 +439,21 @@ func (p *Parser) parseIndexExpression(left my_ast.Expression) my_ast.Expression
 	return exp
 }
 
+// parseMemberExpression: <EXPR>.<IDENT> or <EXPR>?.<IDENT>
+func (p *Parser) parseMemberExpression(left my_ast.Expression) my_ast.Expression {
+	exp := &my_ast.MemberExpression{
+		Left:     left,
+		Optional: p.isCurToken(token.OPT_DOT),
+	}
+	if !p.isPeekToken(token.IDENT) {
+		p.appendTokenError(token.IDENT, p.peekToken)
+		return nil
+	}
+	p.nextToken()
+	exp.Property = &my_ast.Identifier{Value: p.curToken.Literal}
+	return exp
+}
+
 func (p *Parser) parseHashLiteral() my_ast.Expression {
 	hash := &my_ast.HashExpression{
 		Pairs: make(map[my_ast.Expression]my_ast.Expression),
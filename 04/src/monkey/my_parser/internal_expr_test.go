@@ -36,6 +36,12 @@ func TestParseNumberStatement(t *testing.T) {
 	assert.Nil(t, p.err)
 	assert.EqualValues(t, 1, prog.Statements[0].(*my_ast.ExpressionStatement).Expression.(*my_ast.Integer).Value)
 	assert.EqualValues(t, 1.234, prog.Statements[1].(*my_ast.ExpressionStatement).Expression.(*my_ast.Float).Value)
+
+	p = New(lexer.New("18446744073709551615;18446744073709551616"))
+	prog = p.Parse()
+	assert.Nil(t, p.err)
+	assert.EqualValues(t, uint64(18446744073709551615), prog.Statements[0].(*my_ast.ExpressionStatement).Expression.(*my_ast.Integer).Value)
+	assert.Equal(t, "18446744073709551616", prog.Statements[1].(*my_ast.ExpressionStatement).Expression.(*my_ast.BigInteger).Value.String())
 }
 
 func TestPrefixExpressionStatement(t *testing.T) {
@@ -102,6 +108,7 @@ func TestOperatorPrecedence(t *testing.T) {
 		{"a*b-c", "((a*b)-c);"},
 		{"!-c", "(!(-c));"},
 		{"-1+2", "((-1)+2);"},
+		{"a+b%c*d", "(a+((b%c)*d));"},
 	}
 	testStringedStatements(t, tests)
 }
@@ -200,7 +207,7 @@ func TestStringLiteral(t *testing.T) {
 	assert.True(t, eok)
 	ss, sok := es.Expression.(*my_ast.StringExpression)
 	assert.True(t, sok)
-	assert.Equal(t, `Hello\tWorld!\n`, ss.Value)
+	assert.Equal(t, "Hello\tWorld!\n", ss.Value)
 }
 
 func TestParseArrayExpression(t *testing.T) {
@@ -234,3 +241,140 @@ func TestParseMapExpression(t *testing.T) {
 	}
 	testStringedStatements(t, tests)
 }
+
+func TestTupleExpression(t *testing.T) {
+	tests := []struct {
+		input  string
+		expect string
+	}{
+		{"()", "();"},
+		{"(1)", "1;"},
+		{"(1,)", "(1,);"},
+		{"(1, 2 + 3)", "(1,(2+3));"},
+		{"(1, (2, 3),)", "(1,(2,3));"},
+		{"[(1, 2)][0]", "([(1,2)][0]);"},
+	}
+	for _, test := range tests {
+		p := New(lexer.New(test.input))
+		prog := p.Parse()
+		assert.Nil(t, p.Error(), "input: %s", test.input)
+		assert.Equal(t, test.expect, prog.String())
+	}
+
+	p := New(lexer.New("(1, 2"))
+	p.Parse()
+	assert.ErrorIs(t, p.Error(), ErrParseError)
+}
+
+func TestNullishAndMemberExpression(t *testing.T) {
+	tests := []struct {
+		input  string
+		expect string
+	}{
+		{"null", "null;"},
+		{"a ?? b", "(a??b);"},
+		{"a ?? b ?? c", "((a??b)??c);"},
+		{"a ?? 1 + 2 == 3", "(a??((1+2)==3));"},
+		{"a.b.c", "((a.b).c);"},
+		{"a?.b", "(a?.b);"},
+		{"a?.b[0]", "((a?.b)[0]);"},
+		{"a?[0]?[1:]", "((a?[0])?[1:]);"},
+		{"-a.b", "(-(a.b));"},
+		{"a.f(1)", "(a.f)(1);"},
+		{"h?.x ?? 0", "((h?.x)??0);"},
+	}
+	for _, test := range tests {
+		p := New(lexer.New(test.input))
+		prog := p.Parse()
+		assert.Nil(t, p.Error(), "input: %s", test.input)
+		assert.Equal(t, test.expect, prog.String())
+	}
+
+	p := New(lexer.New("a.1"))
+	p.Parse()
+	assert.ErrorIs(t, p.Error(), ErrParseError)
+}
+
+func TestInterpolatedString(t *testing.T) {
+	tests := []struct {
+		input  string
+		expect string
+	}{
+		{`"hello ${name}, you are ${age + 1}"`, `"hello ${name}, you are ${(age+1)}";`},
+		{`"${"in ${x}"}!"`, `"${"in ${x}"}!";`},
+		{`"a" + "${b}"`, `(a+"${b}");`},
+	}
+	for _, test := range tests {
+		p := New(lexer.New(test.input))
+		prog := p.Parse()
+		assert.Nil(t, p.Error(), "input: %s", test.input)
+		assert.Equal(t, test.expect, prog.String())
+	}
+
+	p := New(lexer.New(`"a ${} b"`))
+	p.Parse()
+	assert.EqualError(t, p.Error(), "empty expression in string interpolation: parse error")
+	p = New(lexer.New(`"a ${1 2} b"`))
+	p.Parse()
+	assert.ErrorIs(t, p.Error(), ErrParseError)
+}
+
+func TestNumberLiterals(t *testing.T) {
+	integers := map[string]uint64{
+		"0xFF":       255,
+		"0XfF":       255,
+		"0o17":       15,
+		"0b1010":     10,
+		"1_000_000":  1000000,
+		"0x_ff_ff":   65535,
+		"0755":       755,
+		"0xFFFFFFFF": 4294967295,
+	}
+	for input, expect := range integers {
+		p := New(lexer.New(input))
+		prog := p.Parse()
+		assert.Nil(t, p.Error(), "input: %s", input)
+		assert.Equal(t, expect, prog.Statements[0].(*my_ast.ExpressionStatement).Expression.(*my_ast.Integer).Value, "input: %s", input)
+	}
+
+	floats := map[string]float64{
+		"1e9":     1e9,
+		"1.5E-3":  1.5e-3,
+		"2e+2":    200,
+		".5":      0.5,
+		"1_0.2_5": 10.25,
+		"1E0_1":   10,
+	}
+	for input, expect := range floats {
+		p := New(lexer.New(input))
+		prog := p.Parse()
+		assert.Nil(t, p.Error(), "input: %s", input)
+		assert.Equal(t, expect, prog.Statements[0].(*my_ast.ExpressionStatement).Expression.(*my_ast.Float).Value, "input: %s", input)
+	}
+
+	p := New(lexer.New("0x1_0000_0000_0000_0000"))
+	prog := p.Parse()
+	assert.Nil(t, p.Error())
+	assert.Equal(t, "18446744073709551616", prog.String()[:20])
+
+	p = New(lexer.New("let x = 0x;"))
+	p.Parse()
+	assert.EqualError(t, p.Error(), "illegal token: missing digits in hex literal: 0x: parse error")
+	p = New(lexer.New("1 + 1e"))
+	p.Parse()
+	assert.EqualError(t, p.Error(), "illegal token: missing digits in exponent of number literal: 1e: parse error")
+}
+
+func TestIdentifierWithDigits(t *testing.T) {
+	tests := []TestWithExpect{
+		{"x1 + x2", "(x1+x2);"},
+		{"log2(8)", "log2(8);"},
+		{"math.log10(x1)", "(math.log10)(x1);"},
+		{"a1b2*3", "(a1b2*3);"},
+	}
+	testStringedStatements(t, tests)
+
+	p := New(lexer.New("let 1x = 2;"))
+	p.Parse()
+	assert.ErrorIs(t, p.Error(), ErrParseError)
+}
@@ -0,0 +1,83 @@
+package my_repl
+
+import (
+	lexer "monkey/my_lexer"
+	token "monkey/my_token"
+	"os"
+	"strings"
+)
+
+// ANSI escape codes used to colourise input and results
+const (
+	colorReset   = "\x1b[0m"
+	colorRed     = "\x1b[31m"
+	colorGreen   = "\x1b[32m"
+	colorYellow  = "\x1b[33m"
+	colorMagenta = "\x1b[35m"
+	colorCyan    = "\x1b[36m"
+	colorGray    = "\x1b[90m"
+)
+
+// palette: colours by kind of value, no-op if colour is disabled
+type palette struct {
+	enabled bool
+}
+
+func (p palette) paint(color, s string) string {
+	if !p.enabled || s == "" {
+		return s
+	}
+	return color + s + colorReset
+}
+
+func (p palette) keyword(s string) string  { return p.paint(colorMagenta, s) }
+func (p palette) str(s string) string      { return p.paint(colorGreen, s) }
+func (p palette) number(s string) string   { return p.paint(colorCyan, s) }
+func (p palette) constant(s string) string { return p.paint(colorYellow, s) }
+func (p palette) err(s string) string      { return p.paint(colorRed, s) }
+func (p palette) note(s string) string     { return p.paint(colorGray, s) }
+
+// highlight: colourise src by types of the tokens from lexer,
+// keeping whitespaces in between as they are
+func (p palette) highlight(src string) string {
+	if !p.enabled {
+		return src
+	}
+	sb := &strings.Builder{}
+	l := lexer.New(src)
+	start := 0
+	for tok := l.NextToken(); tok.Type != token.EOF; tok = l.NextToken() {
+		end := l.Position()
+		text := src[start:end]
+		trimmed := strings.TrimLeft(text, " \t\r\n")
+		sb.WriteString(text[:len(text)-len(trimmed)])
+		switch tok.Type {
+		case token.TRUE, token.FALSE, token.NULL:
+			sb.WriteString(p.constant(trimmed))
+		case token.INT, token.FLOAT:
+			sb.WriteString(p.number(trimmed))
+		case token.STRING, token.INTERP_BEGIN, token.INTERP_MID, token.INTERP_END:
+			sb.WriteString(p.str(trimmed))
+		case token.ILLEGAL:
+			sb.WriteString(p.err(trimmed))
+		default:
+			if token.LookupKeywords(tok.Type) != "unknown" {
+				sb.WriteString(p.keyword(trimmed))
+			} else {
+				sb.WriteString(trimmed)
+			}
+		}
+		start = end
+	}
+	sb.WriteString(src[start:])
+	return sb.String()
+}
+
+// colorEnabled: colour only if out is a terminal and NO_COLOR is not set
+func colorEnabled(out interface{}) bool {
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return false
+	}
+	f, ok := out.(*os.File)
+	return ok && IsTerminal(f)
+}
@@ -0,0 +1,137 @@
+package my_repl
+
+import (
+	"fmt"
+	"io"
+	"monkey/my_ast"
+	lexer "monkey/my_lexer"
+	object "monkey/my_object"
+	parser "monkey/my_parser"
+	token "monkey/my_token"
+	"os"
+	"sort"
+	"strings"
+)
+
+// COMMAND_PREFIX: lines starting with it are repl commands instead of monkey codes
+const COMMAND_PREFIX = ":"
+
+type command struct {
+	usage string
+	help  string
+	run   func(s *session, arg string)
+}
+
+var commands map[string]*command
+
+func init() {
+	// NOTE: assigned in init since :help refers to commands itself
+	commands = map[string]*command{
+		"tokens": {
+			usage: ":tokens <src>",
+			help:  "print tokens of src produced by the lexer",
+			run:   (*session).commandTokens,
+		},
+		"ast": {
+			usage: ":ast <src>",
+			help:  "print the syntax tree of src produced by the parser",
+			run:   (*session).commandAst,
+		},
+		"env": {
+			usage: ":env",
+			help:  "list bindings in the session environment",
+			run:   (*session).commandEnv,
+		},
+		"load": {
+			usage: ":load <file.mk>",
+			help:  "evaluate a script file in the session environment",
+			run:   (*session).commandLoad,
+		},
+		"reset": {
+			usage: ":reset",
+			help:  "drop all bindings in the session environment",
+			run:   (*session).commandReset,
+		},
+		"help": {
+			usage: ":help",
+			help:  "show this message",
+			run:   (*session).commandHelp,
+		},
+	}
+}
+
+// runCommand: run line such as `:ast 1 + 2`
+func (s *session) runCommand(line string) {
+	name, arg := line[len(COMMAND_PREFIX):], ""
+	if idx := strings.IndexAny(name, " \t"); idx >= 0 {
+		name, arg = name[:idx], strings.TrimSpace(name[idx:])
+	}
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(s.out, "unknown command %s%s, see :help\n", COMMAND_PREFIX, name)
+		return
+	}
+	cmd.run(s, arg)
+}
+
+func (s *session) commandTokens(arg string) {
+	if arg == "" {
+		fmt.Fprintf(s.out, "usage: %s\n", commands["tokens"].usage)
+		return
+	}
+	l := lexer.New(arg)
+	for tok := l.NextToken(); tok.Type != token.EOF; tok = l.NextToken() {
+		fmt.Fprintf(s.out, "%-10s %q\n", tok.Type, tok.Literal)
+	}
+}
+
+func (s *session) commandAst(arg string) {
+	if arg == "" {
+		fmt.Fprintf(s.out, "usage: %s\n", commands["ast"].usage)
+		return
+	}
+	p := parser.New(lexer.New(arg))
+	program := p.Parse()
+	if p.Error() != nil {
+		printParserErrors(s.out, []string{p.Error().Error()})
+		return
+	}
+	io.WriteString(s.out, my_ast.Dump(program))
+	io.WriteString(s.out, "\n")
+}
+
+func (s *session) commandEnv(arg string) {
+	for _, name := range s.env.Names() {
+		value, _ := s.env.Get(name)
+		fmt.Fprintf(s.out, "%s = %s\n", name, s.palette.inspect(value))
+	}
+}
+
+func (s *session) commandLoad(arg string) {
+	if arg == "" {
+		fmt.Fprintf(s.out, "usage: %s\n", commands["load"].usage)
+		return
+	}
+	src, err := os.ReadFile(arg)
+	if err != nil {
+		fmt.Fprintln(s.out, err)
+		return
+	}
+	s.eval(string(src))
+}
+
+func (s *session) commandReset(arg string) {
+	s.env = object.NewEnvironment()
+}
+
+func (s *session) commandHelp(arg string) {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(s.out, "%-18s %s\n", commands[name].usage, commands[name].help)
+	}
+	fmt.Fprintf(s.out, "%-18s %s\n", "exit", "leave the repl")
+}
@@ -0,0 +1,33 @@
+package my_repl
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommands(t *testing.T) {
+	script := filepath.Join(t.TempDir(), "script.mk")
+	assert.NoError(t, os.WriteFile(script, []byte("let b = a * 2;"), 0o644))
+
+	tests := []struct {
+		input  string
+		expect string
+	}{
+		{":tokens let a = 'x';", "LET        \"let\"\nIDENT      \"a\"\n=          \"=\"\nSTRING     \"x\"\n;          \";\"\n"},
+		{":ast -1", "Program\n  Statements[0]: ExpressionStatement\n    Expression: PrefixExpression Operator=\"-\"\n      Right: Integer Value=1\n"},
+		{"let a = 1;\n:load " + script + "\n:env", "a = 1 (INT)\nb = 2 (INT)\n"},
+		{"let s = 'x';\nlet t = (s, [1]);\n:env", "s = \"x\" (STRING)\nt = (\n  \"x\",\n  [1]\n) (TUPLE)\n"},
+		{"let a = 1;\n:reset\n:env", ""},
+		{":nope", "unknown command :nope, see :help\n"},
+		{":ast", "usage: :ast <src>\n"},
+	}
+	for _, test := range tests {
+		out := &strings.Builder{}
+		Start(strings.NewReader(test.input), out)
+		assert.Equal(t, test.expect+"\n", out.String(), "input: %q", test.input)
+	}
+}
@@ -0,0 +1,50 @@
+package my_repl
+
+import (
+	evaluator "monkey/my_evaluator"
+	token "monkey/my_token"
+	"sort"
+	"strings"
+)
+
+// complete: completions of the word before pos in line,
+// from keywords, builtins, modules and names bound in the session environment;
+// repl commands are completed instead if line starts with COMMAND_PREFIX
+func (s *session) complete(line string, pos int) (head string, completions []string, tail string) {
+	head, tail = line[:pos], line[pos:]
+	start := len(head)
+	for start > 0 && isIdentChar(head[start-1]) {
+		start--
+	}
+	word := head[start:]
+	head = head[:start]
+
+	candidates := []string{}
+	if strings.HasPrefix(head, COMMAND_PREFIX) && !strings.ContainsAny(head, " \t") {
+		for name := range commands {
+			candidates = append(candidates, name)
+		}
+	} else {
+		if word == "" {
+			return head, nil, tail
+		}
+		candidates = append(candidates, token.Keywords()...)
+		candidates = append(candidates, evaluator.BuiltinNames()...)
+		candidates = append(candidates, evaluator.ModuleNames()...)
+		candidates = append(candidates, s.env.Names()...)
+	}
+
+	seen := map[string]bool{}
+	for _, c := range candidates {
+		if strings.HasPrefix(c, word) && !seen[c] {
+			seen[c] = true
+			completions = append(completions, c)
+		}
+	}
+	sort.Strings(completions)
+	return head, completions, tail
+}
+
+func isIdentChar(ch byte) bool {
+	return 'a' <= ch && ch <= 'z' || 'A' <= ch && ch <= 'Z' || ch == '_' || '0' <= ch && ch <= '9'
+}
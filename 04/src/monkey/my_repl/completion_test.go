@@ -0,0 +1,35 @@
+package my_repl
+
+import (
+	object "monkey/my_object"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComplete(t *testing.T) {
+	sess := &session{env: object.NewEnvironment()}
+	sess.env.Set("lenient", &object.Boolean{Value: true})
+	sess.env.Set("rest", &object.Boolean{Value: true})
+
+	tests := []struct {
+		line        string
+		pos         int
+		head        string
+		completions []string
+		tail        string
+	}{
+		{"le", 2, "", []string{"len", "lenient", "let"}, ""},
+		{"1 + re", 6, "1 + ", []string{"reduce", "repeat", "replace", "rest", "return", "reverse"}, ""},
+		{"f(le) + 1", 4, "f(", []string{"len", "lenient", "let"}, ") + 1"},
+		{"zz", 2, "", nil, ""},
+		{"1 + ", 4, "1 + ", nil, ""},
+		{":re", 3, ":", []string{"reset"}, ""},
+	}
+	for _, test := range tests {
+		head, completions, tail := sess.complete(test.line, test.pos)
+		assert.Equal(t, test.head, head, "line: %q", test.line)
+		assert.Equal(t, test.completions, completions, "line: %q", test.line)
+		assert.Equal(t, test.tail, tail, "line: %q", test.line)
+	}
+}
@@ -0,0 +1,28 @@
+package my_repl
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+	"golang.org/x/sys/unix"
+)
+
+// echoHighlighted: replace the line just typed in on terminal
+// with its highlighted version, skipped if the line wraps
+func echoHighlighted(prompt, line string, p palette) {
+	ws, err := unix.IoctlGetWinsize(int(os.Stdout.Fd()), unix.TIOCGWINSZ)
+	if err != nil || wraps(prompt, line, int(ws.Col)) {
+		return
+	}
+	// NOTE: cursor up one line, then clear it
+	fmt.Fprint(os.Stdout, "\x1b[1A\r\x1b[2K", prompt, p.highlight(line), "\n")
+}
+
+// wraps: whether prompt and line take more than one row of cols terminal columns,
+// counted in display width since wide characters take two columns;
+// lines recalled from history may have line breaks
+func wraps(prompt, line string, cols int) bool {
+	return strings.Contains(line, "\n") || runewidth.StringWidth(prompt)+runewidth.StringWidth(line) >= cols
+}
@@ -0,0 +1,26 @@
+package my_repl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWraps(t *testing.T) {
+	tests := []struct {
+		line   string
+		cols   int
+		expect bool
+	}{
+		{"1 + 2", 80, false},
+		{"1 + 2", 8, true},
+		// NOTE: 5 runes in 11 bytes, 8 columns wide
+		{`"日本語"`, 12, false},
+		{`"日本語"`, 11, true},
+		{`"héllo"`, 11, false},
+		{"{\n}", 80, true},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expect, wraps(PROMPT, test.line, test.cols), "line: %s, cols: %d", test.line, test.cols)
+	}
+}
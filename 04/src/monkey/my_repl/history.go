@@ -0,0 +1,69 @@
+package my_repl
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/peterh/liner"
+)
+
+// historyEntry: lines of one input as a single history entry,
+// keeping the line breaks so that recalling it gives the same program
+func historyEntry(lines []string) string {
+	return strings.Join(lines, "\n")
+}
+
+var (
+	historyEscaper   = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	historyUnescaper = strings.NewReplacer(`\\`, `\`, `\n`, "\n")
+)
+
+// writeHistory: entries one per line, with backslashes and line breaks escaped
+func writeHistory(w io.Writer, entries []string) error {
+	bw := bufio.NewWriter(w)
+	for _, entry := range entries {
+		bw.WriteString(historyEscaper.Replace(entry))
+		bw.WriteByte('\n')
+	}
+	return bw.Flush()
+}
+
+// readHistory: entries written by writeHistory, at most liner.HistoryLimit latest ones
+func readHistory(r io.Reader) ([]string, error) {
+	entries := []string{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		entries = appendHistory(entries, historyUnescaper.Replace(scanner.Text()))
+	}
+	return entries, scanner.Err()
+}
+
+// appendHistory: entries with entry added as liner does,
+// skipping repeats of the last one and dropping the oldest beyond liner.HistoryLimit
+func appendHistory(entries []string, entry string) []string {
+	if len(entries) > 0 && entries[len(entries)-1] == entry {
+		return entries
+	}
+	entries = append(entries, entry)
+	if len(entries) > liner.HistoryLimit {
+		entries = entries[1:]
+	}
+	return entries
+}
+
+// saveHistory: writes entries to path readable by the user only,
+// as inputs may contain secrets
+func saveHistory(path string, entries []string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	// NOTE: files created before are made private too
+	if err := f.Chmod(0600); err != nil {
+		return err
+	}
+	return writeHistory(f, entries)
+}
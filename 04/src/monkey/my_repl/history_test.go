@@ -0,0 +1,45 @@
+package my_repl
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistoryRoundTrip(t *testing.T) {
+	entries := []string{
+		historyEntry([]string{"let add = fn(x, y) {", "  x + y", "}"}),
+		historyEntry([]string{"let s = `raw", "  text`"}),
+		historyEntry([]string{`let t = """`, `  a ${x}`, `  """`}),
+		`"a\nb\\" + 'c\'`,
+		`trailing \`,
+		"1 + 2",
+	}
+
+	saved := &bytes.Buffer{}
+	assert.NoError(t, writeHistory(saved, entries))
+	assert.Equal(t, len(entries), bytes.Count(saved.Bytes(), []byte("\n")))
+
+	restored, err := readHistory(bytes.NewReader(saved.Bytes()))
+	assert.NoError(t, err)
+	assert.Equal(t, entries, restored)
+	assert.Equal(t, "let s = `raw\n  text`", restored[1])
+}
+
+func TestSaveHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), HISTORY_FILE)
+	assert.NoError(t, os.WriteFile(path, []byte("old\nlonger old entry\n"), 0644))
+
+	assert.NoError(t, saveHistory(path, []string{"let a = {\n}"}))
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	saved, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "let a = {\\n}\n", string(saved))
+
+	assert.Equal(t, []string{"a", "b"}, appendHistory(appendHistory([]string{"a"}, "b"), "b"))
+}
@@ -0,0 +1,40 @@
+package my_repl
+
+import "strings"
+
+// isIncomplete reports whether src stops in the middle of a statement,
+// i.e. with unclosed braces, brackets, parentheses or strings,
+// so that the repl keeps reading lines before evaluating
+func isIncomplete(src string) bool {
+	depth := 0
+	quote := "" // opening quote of the string being scanned, empty if none
+	for idx := 0; idx < len(src); idx++ {
+		ch := src[idx]
+		if quote != "" {
+			switch {
+			case ch == '\\' && quote != "`":
+				idx++
+			case strings.HasPrefix(src[idx:], quote):
+				idx += len(quote) - 1
+				quote = ""
+			}
+			continue
+		}
+		switch ch {
+		case '"', '\'':
+			quote = string(ch)
+			if triple := strings.Repeat(quote, 3); strings.HasPrefix(src[idx:], triple) {
+				quote = triple
+				idx += 2
+			}
+		case '`':
+			quote = "`"
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			depth--
+		}
+	}
+	// NOTE: too many closing brackets are left for the parser to complain
+	return quote != "" || depth > 0
+}
@@ -0,0 +1,42 @@
+package my_repl
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsIncomplete(t *testing.T) {
+	tests := []struct {
+		input  string
+		expect bool
+	}{
+		{"1 + 2", false},
+		{"let add = fn(x, y) {", true},
+		{"let add = fn(x, y) {\n x + y\n}", false},
+		{"[1, 2,", true},
+		{"{\"a\": [1, 2]", true},
+		{"add(1,\n", true},
+		{"\"unclosed", true},
+		{"'it\\'s", true},
+		{"'it\\'s'", false},
+		{"\"{\"", false},
+		{"}", false},
+		{"`raw \\", true},
+		{"`raw \\`", false},
+		{"let s = \"\"\"\n  a \"quoted\" line", true},
+		{"let s = \"\"\"\n  a\n  \"\"\"", false},
+		{"'''it's'''", false},
+		{"\"\"", false},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expect, isIncomplete(test.input), "input: %q", test.input)
+	}
+}
+
+func TestStartMultiline(t *testing.T) {
+	out := &strings.Builder{}
+	Start(strings.NewReader("let add = fn(x, y) {\n  x + y\n};\nadd(1,\n 2)\nexit\n"), out)
+	assert.Equal(t, "3 (INT)\n\n", out.String())
+}
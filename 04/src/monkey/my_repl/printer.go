@@ -0,0 +1,123 @@
+package my_repl
+
+import (
+	object "monkey/my_object"
+	"strings"
+)
+
+const printerIndent = "  "
+
+// inspect: format a result for display, like Object.Inspect() but
+// indents nested arrays, tuples and hashes and marks the type
+func (p palette) inspect(obj object.Object) string {
+	if errObj, ok := obj.(*object.Error); ok {
+		return p.err(errObj.String())
+	}
+	sb := &strings.Builder{}
+	p.writeValue(sb, obj, 0)
+	sb.WriteString(" ")
+	sb.WriteString(p.note("(" + string(obj.Type()) + ")"))
+	return sb.String()
+}
+
+func (p palette) writeValue(sb *strings.Builder, obj object.Object, depth int) {
+	switch obj := obj.(type) {
+	case *object.String:
+		sb.WriteString(p.str(obj.Inspect()))
+	case *object.Integer, *object.BigInteger, *object.Float:
+		sb.WriteString(p.number(obj.Inspect()))
+	case *object.Boolean, *object.Null:
+		sb.WriteString(p.constant(obj.Inspect()))
+	case *object.Array:
+		open, close := "[", "]"
+		if obj.Frozen {
+			open, close = "freeze([", "])"
+		}
+		p.writeItems(sb, open, close, p.elementItems(sb, obj.Elements, depth), isFlat(obj.Elements), depth)
+	case *object.Tuple:
+		items := p.elementItems(sb, obj.Elements, depth)
+		// NOTE: a tuple of one element is written (x,), as in its literal
+		if len(items) == 1 {
+			item := items[0]
+			items[0] = func() {
+				item()
+				sb.WriteString(",")
+			}
+		}
+		p.writeItems(sb, "(", ")", items, isFlat(obj.Elements), depth)
+	case *object.Hash:
+		items := make([]func(), 0, obj.Len())
+		values := make([]object.Object, 0, obj.Len())
+		for _, pair := range obj.Pairs() {
+			pair := pair
+			values = append(values, pair.Key, pair.Value)
+			items = append(items, func() {
+				p.writeValue(sb, pair.Key, depth+1)
+				sb.WriteString(": ")
+				p.writeValue(sb, pair.Value, depth+1)
+			})
+		}
+		open, close := "{", "}"
+		if obj.Frozen {
+			open, close = "freeze({", "})"
+		}
+		p.writeItems(sb, open, close, items, isFlat(values), depth)
+	default:
+		sb.WriteString(obj.Inspect())
+	}
+}
+
+// elementItems: writers of elements as items of writeItems
+func (p palette) elementItems(sb *strings.Builder, elements []object.Object, depth int) []func() {
+	items := make([]func(), 0, len(elements))
+	for _, e := range elements {
+		e := e
+		items = append(items, func() { p.writeValue(sb, e, depth+1) })
+	}
+	return items
+}
+
+// writeItems: items on one line if flat, otherwise one item per indented line
+func (p palette) writeItems(sb *strings.Builder, open, close string, items []func(), flat bool, depth int) {
+	sb.WriteString(open)
+	for idx, item := range items {
+		if flat {
+			if idx > 0 {
+				sb.WriteString(", ")
+			}
+		} else {
+			sb.WriteString("\n")
+			sb.WriteString(strings.Repeat(printerIndent, depth+1))
+		}
+		item()
+		if !flat && idx != len(items)-1 {
+			sb.WriteString(",")
+		}
+	}
+	if !flat && len(items) > 0 {
+		sb.WriteString("\n")
+		sb.WriteString(strings.Repeat(printerIndent, depth))
+	}
+	sb.WriteString(close)
+}
+
+// isFlat: if none of objs is a non-empty array, tuple or hash
+func isFlat(objs []object.Object) bool {
+	for _, obj := range objs {
+		switch obj := obj.(type) {
+		case *object.Array:
+			if len(obj.Elements) > 0 {
+				return false
+			}
+		case *object.Tuple:
+			if len(obj.Elements) > 0 {
+				return false
+			}
+		case *object.Hash:
+			if obj.Len() > 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,71 @@
+package my_repl
+
+import (
+	"math/big"
+	evaluator "monkey/my_evaluator"
+	lexer "monkey/my_lexer"
+	object "monkey/my_object"
+	parser "monkey/my_parser"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInspect(t *testing.T) {
+	tests := []struct {
+		input  string
+		expect string
+	}{
+		{`"1"`, `"1" (STRING)`},
+		{`1`, `1 (INT)`},
+		{`["a\n", 1.5, true, []]`, `["a\n", 1.5, true, []] (ARRAY)`},
+		{`[1, [2, {}], {"k": [3]}]`, `[
+  1,
+  [2, {}],
+  {
+    "k": [3]
+  }
+] (ARRAY)`},
+		{`(1, "a")`, `(1, "a") (TUPLE)`},
+		{`(1,)`, `(1,) (TUPLE)`},
+		{`([1],)`, `(
+  [1],
+) (TUPLE)`},
+		{`18446744073709551616`, `18446744073709551616 (INT)`},
+		{`freeze([1, {"a": 2}])`, `freeze([
+  1,
+  freeze({"a": 2})
+]) (ARRAY)`},
+		{`freeze({})`, `freeze({}) (HASH)`},
+		{`len(1)`, `ERROR: argument to len not supported: got INT`},
+	}
+	for _, test := range tests {
+		p := parser.New(lexer.New(test.input))
+		program := p.Parse()
+		assert.NoError(t, p.Error())
+		evaluated := evaluator.Eval(program, object.NewEnvironment())
+		assert.Equal(t, test.expect, palette{}.inspect(evaluated), "input: %q", test.input)
+	}
+
+	tuple := &object.Tuple{Elements: []object.Object{
+		&object.BigInteger{Value: new(big.Int).Lsh(big.NewInt(1), 64)},
+		&object.String{Value: "a"},
+	}}
+	assert.Equal(t,
+		"(\x1b[36m18446744073709551616\x1b[0m, \x1b[32m\"a\"\x1b[0m) \x1b[90m(TUPLE)\x1b[0m",
+		palette{enabled: true}.inspect(tuple),
+	)
+}
+
+func TestHighlight(t *testing.T) {
+	p := palette{enabled: true}
+	assert.Equal(t,
+		"\x1b[35mlet\x1b[0m a = \x1b[36m1\x1b[0m +  \x1b[32m'x'\x1b[0m; \x1b[33mtrue\x1b[0m ",
+		p.highlight("let a = 1 +  'x'; true "),
+	)
+	assert.Equal(t,
+		"\x1b[32m\"\"\"\n  a ${\x1b[0mx\x1b[32m} b\n  \"\"\"\x1b[0m + \x1b[36m1\x1b[0m",
+		p.highlight("\"\"\"\n  a ${x} b\n  \"\"\" + 1"),
+	)
+	assert.Equal(t, "let a = 1", palette{}.highlight("let a = 1"))
+}
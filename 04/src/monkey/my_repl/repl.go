@@ -1,10 +1,14 @@
 package my_repl
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
 	"io"
 	lexer "monkey/my_lexer"
 	"os"
+	"path/filepath"
+	"strings"
 
 	evaluator "monkey/my_evaluator"
 	parser "monkey/my_parser"
@@ -13,43 +17,190 @@ import (
 	// "monkey/parser"
 	object "monkey/my_object"
 
-	console "github.com/xingshuo/console/src"
+	"github.com/peterh/liner"
 )
 
-const PROMPT = ">> "
+const (
+	PROMPT              = ">> "
+	CONTINUATION_PROMPT = ".. "
+	// HISTORY_FILE: history of inputs kept in user's home directory across sessions
+	HISTORY_FILE = ".monkey_history"
+)
 
 func Start(in io.Reader, out io.Writer) {
-	env := object.NewEnvironment()
-	con := console.NewConsole()
-	con.SetKeyDownHook('\x03', func(c *console.Console, s string) {
-		fmt.Fprintln(out, "keyboard interupt.")
-		os.Exit(0)
-	})
-	con.Init(func(c *console.Console, line string) {
-		switch line {
-		case "exit":
-			fallthrough
-		case "exit()":
+	sess := &session{env: object.NewEnvironment(), out: out, palette: palette{enabled: colorEnabled(out)}}
+	reader := newLineReader(in, historyPath(), sess.complete, sess.palette)
+	defer reader.Close()
+
+	// lines of a statement not complete yet, see isIncomplete
+	buffered := []string{}
+	for {
+		prompt := PROMPT
+		if len(buffered) > 0 {
+			prompt = CONTINUATION_PROMPT
+		}
+		line, err := reader.ReadLine(prompt)
+		if errors.Is(err, liner.ErrPromptAborted) {
+			// NOTE: ctrl-c is the escape hatch of buffered input
+			if len(buffered) > 0 {
+				buffered = buffered[:0]
+				fmt.Fprintln(out, "input aborted.")
+				continue
+			}
+			fmt.Fprintln(out, "keyboard interupt.")
+			return
+		}
+		if err != nil {
 			fmt.Fprintln(out)
-			os.Exit(0)
-		default:
-			l := lexer.New(line)
-			p := parser.New(l)
-
-			program := p.Parse()
-			if p.Error() != nil {
-				printParserErrors(out, []string{p.Error().Error()})
+			return
+		}
+		if len(buffered) == 0 {
+			trimmed := strings.TrimSpace(line)
+			switch {
+			case trimmed == "":
+				continue
+			case trimmed == "exit" || trimmed == "exit()":
+				fmt.Fprintln(out)
 				return
+			case strings.HasPrefix(trimmed, COMMAND_PREFIX):
+				reader.AppendHistory(trimmed)
+				sess.runCommand(trimmed)
+				continue
 			}
-			evaluated := evaluator.Eval(program, env)
-			if evaluated != nil {
-				io.WriteString(out, "\n")
-				io.WriteString(out, evaluated.String())
+		}
+		buffered = append(buffered, line)
+		src := strings.Join(buffered, "\n")
+		if isIncomplete(src) {
+			continue
+		}
+		reader.AppendHistory(historyEntry(buffered))
+		buffered = buffered[:0]
+		sess.eval(src)
+	}
+}
+
+// session: states kept across inputs of one repl
+type session struct {
+	env     *object.Environment
+	out     io.Writer
+	palette palette
+}
+
+// eval: parse and evaluate src in the session environment and print the result
+func (s *session) eval(src string) {
+	l := lexer.New(src)
+	p := parser.New(l)
+
+	program := p.Parse()
+	if p.Error() != nil {
+		printParserErrors(s.out, []string{p.Error().Error()})
+		return
+	}
+	evaluated := evaluator.Eval(program, s.env)
+	if evaluated != nil {
+		io.WriteString(s.out, s.palette.inspect(evaluated))
+		io.WriteString(s.out, "\n")
+	}
+}
+
+type lineReader interface {
+	// ReadLine: read one line without its line break, io.EOF if no more input
+	ReadLine(prompt string) (string, error)
+	AppendHistory(line string)
+	Close() error
+}
+
+// newLineReader: a line editor with history and completion if in is a terminal,
+// otherwise a plain reader printing no prompts;
+// history is loaded from historyPath if not empty and saved back on Close;
+// lines read are echoed again highlighted by palette;
+// NOTE: liner is used as it takes a prompt per line, which continuation prompts need,
+// and returns ctrl-c as an error instead of running a callback
+func newLineReader(in io.Reader, historyPath string, completer liner.WordCompleter, palette palette) lineReader {
+	if f, ok := in.(*os.File); ok && IsTerminal(f) && liner.TerminalSupported() {
+		state := liner.NewLiner()
+		state.SetCtrlCAborts(true)
+		state.SetWordCompleter(completer)
+		state.SetTabCompletionStyle(liner.TabPrints)
+		reader := &terminalReader{state: state, historyPath: historyPath, palette: palette}
+		if historyPath != "" {
+			if f, err := os.Open(historyPath); err == nil {
+				reader.history, _ = readHistory(f)
+				f.Close()
+			}
+			for _, entry := range reader.history {
+				state.AppendHistory(entry)
 			}
-			return
 		}
-	})
-	con.LoopCmd()
+		return reader
+	}
+	return &plainReader{scanner: bufio.NewScanner(in)}
+}
+
+// terminalReader: keeps its own copy of the history entries,
+// since liner saves them one per line with their line breaks unescaped
+type terminalReader struct {
+	state       *liner.State
+	historyPath string
+	history     []string
+	palette     palette
+}
+
+func (t *terminalReader) ReadLine(prompt string) (string, error) {
+	line, err := t.state.Prompt(prompt)
+	if err == nil && t.palette.enabled {
+		echoHighlighted(prompt, line, t.palette)
+	}
+	return line, err
+}
+
+func (t *terminalReader) AppendHistory(line string) {
+	t.state.AppendHistory(line)
+	t.history = appendHistory(t.history, line)
+}
+
+func (t *terminalReader) Close() error {
+	defer t.state.Close()
+	if t.historyPath == "" {
+		return nil
+	}
+	return saveHistory(t.historyPath, t.history)
+}
+
+// historyPath: path of HISTORY_FILE, empty if home directory is unknown
+func historyPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, HISTORY_FILE)
+}
+
+type plainReader struct {
+	scanner *bufio.Scanner
+}
+
+func (p *plainReader) ReadLine(prompt string) (string, error) {
+	if !p.scanner.Scan() {
+		if err := p.scanner.Err(); err != nil {
+			return "", err
+		}
+		return "", io.EOF
+	}
+	return p.scanner.Text(), nil
+}
+
+func (p *plainReader) AppendHistory(line string) {}
+
+func (p *plainReader) Close() error { return nil }
+
+// IsTerminal reports whether f is a terminal rather than a file or a pipe
+func IsTerminal(f *os.File) bool {
+	stat, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return stat.Mode()&os.ModeCharDevice != 0
 }
 
 const MONKEY_FACE = `            __,__
@@ -1,5 +1,7 @@
 package my_token
 
+import "sort"
+
 type TokenType string
 
 const (
@@ -12,6 +14,12 @@ const (
 	FLOAT  = "FLOAT"
 	STRING = "STRING"
 
+	// parts of an interpolated string "a ${x} b ${y} c":
+	// INTERP_BEGIN "a ", x, INTERP_MID " b ", y, INTERP_END " c"
+	INTERP_BEGIN = "INTERP_BEGIN"
+	INTERP_MID   = "INTERP_MID"
+	INTERP_END   = "INTERP_END"
+
 	// Operators
 	ASSIGN   = "="
 	PLUS     = "+"
@@ -19,6 +27,7 @@ const (
 	BANG     = "!"
 	ASTERISK = "*"
 	SLASH    = "/"
+	PERCENT  = "%"
 
 	LT = "<"
 	GT = ">"
@@ -26,6 +35,11 @@ const (
 	EQ     = "=="
 	NOT_EQ = "!="
 
+	NULLISH      = "??"
+	DOT          = "."
+	OPT_DOT      = "?."
+	OPT_LBRACKET = "?["
+
 	// Delimiters
 	COMMA     = ","
 	SEMICOLON = ";"
@@ -46,6 +60,7 @@ const (
 	IF       = "IF"
 	ELSE     = "ELSE"
 	RETURN   = "RETURN"
+	NULL     = "NULL"
 )
 
 type Token struct {
@@ -61,6 +76,17 @@ var keywords = map[string]TokenType{
 	"if":     IF,
 	"else":   ELSE,
 	"return": RETURN,
+	"null":   NULL,
+}
+
+// Keywords: all keywords in the order of their literals
+func Keywords() []string {
+	kws := make([]string, 0, len(keywords))
+	for kw := range keywords {
+		kws = append(kws, kw)
+	}
+	sort.Strings(kws)
+	return kws
 }
 
 func LookupIdent(ident string) TokenType {
@@ -78,6 +104,7 @@ var kwreversed = map[TokenType]string{
 	IF:       "if",
 	ELSE:     "else",
 	RETURN:   "return",
+	NULL:     "null",
 }
 
 func LookupKeywords(t TokenType) string {